@@ -2,18 +2,38 @@ package main
 
 import (
 	"bufio"
-	"crypto/tls"
-	"encoding/json"
+	"context"
 	"flag"
 	"fmt"
+	"github.com/MillerMedia/kneejerk/internal/crawler"
+	"github.com/MillerMedia/kneejerk/internal/discover"
+	"github.com/MillerMedia/kneejerk/internal/render"
+	"github.com/MillerMedia/kneejerk/internal/report"
+	"github.com/MillerMedia/kneejerk/internal/scope"
+	"github.com/MillerMedia/kneejerk/internal/scraper"
+	"github.com/MillerMedia/kneejerk/internal/sourcemap"
 	"github.com/PuerkitoBio/goquery"
 	"io"
-	"net/http"
 	"os"
 	"regexp"
 	"strings"
+	"sync"
+	"time"
 )
 
+// stringList collects every occurrence of a repeated flag (e.g.
+// `-scope +a.com -scope -b.com`) into a slice, implementing flag.Value.
+type stringList []string
+
+func (l *stringList) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *stringList) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
+
 // ASCII Banner
 const banner = `
  _  __                _           _    
@@ -29,203 +49,476 @@ const banner = `
 // Pattern for .js files
 var jsFilePattern = regexp.MustCompile(`.*\.js`)
 
-// Regex to find API path patterns
-var apiPathPattern = regexp.MustCompile(`"(GET|POST|PUT|DELETE|PATCH)",\s*"(/v\d+[^"]*)"`)
-
+// foundVars dedups findings across every worker goroutine; stateMu guards
+// it, since a plain map isn't safe for concurrent use.
 var foundVars = map[string]struct{}{}
+var stateMu sync.Mutex
+
+// scanOptions bundles the CLI knobs that shape how a single target URL is
+// scanned, so adding another one doesn't mean threading a new parameter
+// through every function between main and where it's used.
+type scanOptions struct {
+	concurrency    int
+	debug          bool
+	dumpSourcesDir string
+	// pathFilter restricts which <script src> URLs (or, in render mode,
+	// which loaded JS resources) get scanned to those containing this
+	// substring. Empty means no filtering.
+	pathFilter string
+	// renderDriver, when non-nil, discovers JS resources by loading the
+	// page in a headless browser instead of parsing its initial HTML.
+	renderDriver  render.Driver
+	renderTimeout time.Duration
+	// scope decides whether an absolute API endpoint found in a JS file is
+	// in-scope to report, relative to that file's URL.
+	scope *scope.Scope
+}
 
-var outputFileWriter *bufio.Writer = nil
-
-// Regex to find environment variables directly assigned
-var directEnvVarPattern = regexp.MustCompile(`\b(?:NODE|REACT_APP|AWS)_?[A-Z_]*\b\s*:\s*".*?"`)
-
-func scrapeEnvVars(jsURL string, jsContent string) {
-	// First, check for direct assignments
-	directMatches := directEnvVarPattern.FindAllString(jsContent, -1)
-	for _, match := range directMatches {
-		if _, ok := foundVars[match]; !ok {
-			foundVars[match] = struct{}{}
-			severity := determineSeverity(match)
-			coloredMessage, uncoloredMessage := colorizeMessage("kneejerk", "env-var", severity, jsURL, match)
-			fmt.Println(coloredMessage)
-			if outputFileWriter != nil {
-				_, _ = outputFileWriter.WriteString(uncoloredMessage + "\n")
-				_ = outputFileWriter.Flush()
-			}
-		}
+// seen reports whether key has already been recorded, atomically recording
+// it if not. Safe for concurrent use.
+func seen(key string) bool {
+	stateMu.Lock()
+	defer stateMu.Unlock()
+	if _, ok := foundVars[key]; ok {
+		return true
 	}
+	foundVars[key] = struct{}{}
+	return false
 }
 
-// Scrape APIs
-func scrapeAPIPaths(jsURL string, jsContent string, debug bool) {
-	debugLog(debug, "Debug: Scanning for API paths in %s...\n", jsURL)
-
-	// Check for patterns like "POST", "/v1/accounts:signInWithPhoneNumber",
-	matches := apiPathPattern.FindAllStringSubmatch(jsContent, -1)
-	for _, match := range matches {
-		debugLog(debug, "Debug: Found API path match: %s\n", match)
-		if _, ok := foundVars[match[0]]; !ok {
-			foundVars[match[0]] = struct{}{}
-			printAPI(debug, jsURL, match[1], match[2])
+// runScrapers runs every registered scraper against content and reports
+// each new finding, deduping against foundVars. When sm is non-nil, each
+// match's offset is translated through the source map's VLQ mappings to
+// attribute it back to the pre-bundle file/line it came from.
+func runScrapers(reporter report.Reporter, registry *scraper.Registry, sourceURL string, jsURL string, content string, opts scanOptions, sm *sourcemap.Map) {
+	for _, m := range registry.Scan(content) {
+		key := strings.Join([]string{m.ScraperID, m.Text, m.Method, m.Endpoint}, "|")
+		if seen(key) {
+			continue
 		}
-	}
 
-	axiosPathRE := regexp.MustCompile(`axios\.(get|post|put|delete|patch)\(\s*['"]([^'"]+)['"]`)
-	fetchPathRE := regexp.MustCompile(`fetch\(\s*['"]([^'"]+)['"],[\s\S]*?{[\s\S]*?method\s*:\s*['"]([^'"]+)['"]`)
-	ajaxPathRE := regexp.MustCompile(`\$\.ajax\(\s*{\s*url\s*:\s*['"]([^'"]+)['"],[\s\S]*?type\s*:\s*['"]([^'"]+)['"]`)
-
-	axiosMatches := axiosPathRE.FindAllStringSubmatch(jsContent, -1)
+		if m.Output == scraper.OutputAPI {
+			if len(m.Method) > 12 {
+				debugLog(opts.debug, "Debug: Ignoring API path due to method length (possible false positive): [%s, %s]\n", m.Method, m.Endpoint)
+				continue
+			}
+			if !apiInScope(opts.scope, jsURL, m.Endpoint) {
+				continue
+			}
+			debugLog(opts.debug, "Debug: Found API match [%s]: %s %s\n", m.ScraperID, m.Method, m.Endpoint)
+		}
 
-	// Swap method and endpoint in axiosMatches
-	for i, match := range axiosMatches {
-		if len(match) > 2 {
-			axiosMatches[i] = []string{match[0], match[2], match[1]}
+		finding := report.Finding{
+			TemplateID: "kneejerk",
+			ScraperID:  m.ScraperID,
+			Output:     m.Output,
+			Severity:   m.Severity,
+			SourceURL:  sourceURL,
+			JSURL:      jsURL,
+			Method:     m.Method,
+			Endpoint:   m.Endpoint,
+			Match:      m.Text,
+			Offset:     m.Offset,
 		}
-	}
 
-	fetchMatches := fetchPathRE.FindAllStringSubmatch(jsContent, -1)
-	ajaxMatches := ajaxPathRE.FindAllStringSubmatch(jsContent, -1)
-
-	var allMatches [][]string
-	allMatches = append(allMatches, axiosMatches...)
-	allMatches = append(allMatches, fetchMatches...)
-	allMatches = append(allMatches, ajaxMatches...)
-
-	for _, match := range allMatches {
-		if len(match) > 1 {
-			method := strings.ToUpper(match[2]) // Convert the method to uppercase
-			endpoint := strings.ReplaceAll(match[1], `${}`, "")
-			debugLog(debug, "Debug: Found AJAX endpoint: [%s, %s]\n", method, endpoint)
-			if _, ok := foundVars[endpoint]; !ok {
-				foundVars[endpoint] = struct{}{}
-				printAPI(debug, jsURL, method, endpoint)
+		if sm != nil {
+			genLine, genCol := sourcemap.LineCol(content, m.Offset)
+			if file, line, ok := sm.OriginalPosition(genLine, genCol); ok {
+				finding.OriginalFile = file
+				finding.OriginalLine = line
 			}
 		}
+
+		reporter.Report(finding)
 	}
 }
 
-func scrapeJSFiles(u string, debug bool) {
+// scrapeJSFiles fetches u, extracts every <script src> it references, and
+// fans those JS URLs out across pool's worker goroutines for scraping. When
+// opts.renderDriver is set, script discovery is delegated to
+// scrapeRenderedJSFiles instead, so scripts a SPA injects at runtime are
+// seen too.
+func scrapeJSFiles(ctx context.Context, pool *crawler.Pool, registry *scraper.Registry, reporter report.Reporter, u string, opts scanOptions) {
 	// Remove ANSI escape sequences from the URL
 	cleanUrl := removeANSI(u)
 
-	res, err := http.Get(cleanUrl)
-	if err != nil {
-		fmt.Printf("Failed to get %s: %v\n", u, err)
+	if opts.renderDriver != nil {
+		scrapeRenderedJSFiles(ctx, pool, registry, reporter, cleanUrl, opts)
+		return
+	}
+
+	pageResult := <-pool.Fetch(ctx, 1, []string{cleanUrl})
+	if pageResult.Err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to get %s: %v\n", u, pageResult.Err)
 		return
 	}
-	defer res.Body.Close()
 
-	doc, err := goquery.NewDocumentFromReader(res.Body)
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(string(pageResult.Body)))
 	if err != nil {
-		fmt.Printf("Failed to parse %s: %v\n", u, err)
+		fmt.Fprintf(os.Stderr, "Failed to parse %s: %v\n", u, err)
 		return
 	}
 
+	var jsURLs []string
 	processedJs := make(map[string]bool)
 
 	doc.Find("script").Each(func(i int, s *goquery.Selection) {
 		src, _ := s.Attr("src")
-		if src != "" && strings.Contains(src, "/static/") && jsFilePattern.MatchString(src) {
-			jsURL := urlJoin(u, src)
-
-			// Skip if this JS file has been processed
+		if src != "" && matchesPathFilter(src, opts.pathFilter) && jsFilePattern.MatchString(src) {
+			jsURL := urlJoin(cleanUrl, src)
 			if processedJs[jsURL] {
 				return
 			}
 			processedJs[jsURL] = true
+			jsURLs = append(jsURLs, jsURL)
+		}
+	})
 
-			jsRes, err := http.Get(jsURL)
-			if err != nil {
-				fmt.Printf("Failed to get %s: %v\n", jsURL, err)
-				return
-			}
-			defer jsRes.Body.Close()
+	if len(jsURLs) == 0 {
+		return
+	}
 
-			jsContent, err := io.ReadAll(jsRes.Body)
-			if err != nil {
-				fmt.Printf("Failed to read %s: %v\n", jsURL, err)
-				return
+	for result := range pool.Fetch(ctx, opts.concurrency, jsURLs) {
+		if result.Err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to get %s: %v\n", result.URL, result.Err)
+			continue
+		}
+		processJS(ctx, pool, registry, reporter, cleanUrl, result.URL, removeANSI(string(result.Body)), opts)
+	}
+}
+
+// scrapeRenderedJSFiles discovers JS resources by loading u in a headless
+// browser and capturing every JS response the page triggers while it
+// renders, rather than parsing only the scripts present in the initial
+// HTML. Resource bodies come back from the render itself, so unlike
+// scrapeJSFiles there's no second fetch before scraping them.
+func scrapeRenderedJSFiles(ctx context.Context, pool *crawler.Pool, registry *scraper.Registry, reporter report.Reporter, u string, opts scanOptions) {
+	renderCtx, cancel := context.WithTimeout(ctx, opts.renderTimeout)
+	defer cancel()
+
+	resources, err := opts.renderDriver.Render(renderCtx, u)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to render %s: %v\n", u, err)
+		return
+	}
+
+	processedJs := make(map[string]bool)
+	for _, resource := range resources {
+		if !matchesPathFilter(resource.URL, opts.pathFilter) || processedJs[resource.URL] {
+			continue
+		}
+		processedJs[resource.URL] = true
+		processJS(ctx, pool, registry, reporter, u, resource.URL, removeANSI(string(resource.Body)), opts)
+	}
+}
+
+// matchesPathFilter reports whether a JS URL should be scanned: every URL
+// passes when filter is empty, otherwise it must contain filter.
+func matchesPathFilter(jsURL string, filter string) bool {
+	return filter == "" || strings.Contains(jsURL, filter)
+}
+
+// scanSeed scans seedURL for JS the same way scrapeJSFiles always has. When
+// useRobots, useSitemap, or maxDepth asks for it, it first crawls outward
+// from seedURL to find more pages worth scanning, so a single seed URL can
+// uncover an entire app's script surface.
+func scanSeed(ctx context.Context, pool *crawler.Pool, registry *scraper.Registry, reporter report.Reporter, seedURL string, opts scanOptions, useRobots bool, useSitemap bool, maxDepth int) {
+	if !useRobots && !useSitemap && maxDepth <= 0 {
+		scrapeJSFiles(ctx, pool, registry, reporter, seedURL, opts)
+		return
+	}
+	crawlSeeds(ctx, pool, registry, reporter, seedURL, opts, useRobots, useSitemap, maxDepth)
+}
+
+// crawlSeeds scans seedURL and every page reachable from it: robots.txt and
+// sitemap.xml entries (when requested), plus pages found by following
+// in-page anchors and JS-referenced URLs up to maxDepth hops, bounded by
+// opts.scope so the crawl doesn't wander off-site.
+func crawlSeeds(ctx context.Context, pool *crawler.Pool, registry *scraper.Registry, reporter report.Reporter, seedURL string, opts scanOptions, useRobots bool, useSitemap bool, maxDepth int) {
+	queue := []string{seedURL}
+	for _, s := range discoverSeeds(ctx, pool, seedURL, opts, useRobots, useSitemap) {
+		if opts.scope.InScope(seedURL, s) {
+			queue = append(queue, s)
+		}
+	}
+	visited := make(map[string]bool)
+
+	for depth := 0; len(queue) > 0; depth++ {
+		var next []string
+		for _, pageURL := range queue {
+			if visited[pageURL] {
+				continue
 			}
+			visited[pageURL] = true
+
+			scrapeJSFiles(ctx, pool, registry, reporter, pageURL, opts)
 
-			// Remove ANSI escape sequences
-			cleanJsContent := removeANSI(string(jsContent))
-
-			// Call the specific scraping functions
-			scrapeEnvVars(jsURL, cleanJsContent)
-			scrapeAPIPaths(jsURL, cleanJsContent, debug)
-
-			// Check for sourceMappingURL
-			if strings.HasSuffix(cleanJsContent, ".map") {
-				lines := strings.Split(cleanJsContent, "\n")
-				lastLine := lines[len(lines)-1]
-				if strings.HasPrefix(lastLine, "//# sourceMappingURL=") {
-					mapFileName := strings.TrimPrefix(lastLine, "//# sourceMappingURL=")
-					mapFileUrl := urlJoin(jsURL, mapFileName)
-					debugLog(debug, "Debug: Fetching source map: %s\n", mapFileUrl)
-					mapFileRes, err := http.Get(mapFileUrl)
-					if err != nil {
-						fmt.Printf("Failed to get %s: %v\n", mapFileUrl, err)
-						return
-					}
-					defer mapFileRes.Body.Close()
-
-					mapFileContent, err := io.ReadAll(mapFileRes.Body)
-					if err != nil {
-						fmt.Printf("Failed to read %s: %v\n", mapFileUrl, err)
-						return
-					}
-
-					var sourceMap struct {
-						SourcesContent []string `json:"sourcesContent"`
-					}
-
-					err = json.Unmarshal(mapFileContent, &sourceMap)
-					if err != nil {
-						fmt.Printf("Failed to parse source map %s: %v\n", mapFileUrl, err)
-						return
-					}
-
-					for _, sourceContent := range sourceMap.SourcesContent {
-						// Remove ANSI escape sequences
-						cleanSourceContent := removeANSI(sourceContent)
-
-						// Call the specific scraping functions
-						scrapeEnvVars(mapFileUrl, cleanSourceContent)
-						scrapeAPIPaths(mapFileUrl, cleanSourceContent, debug)
-					}
+			if depth >= maxDepth {
+				continue
+			}
+			for _, link := range discoverLinks(ctx, pool, pageURL, opts) {
+				if !visited[link] && opts.scope.InScope(seedURL, link) {
+					next = append(next, link)
 				}
 			}
 		}
+		queue = next
+	}
+}
+
+// discoverSeeds gathers extra page URLs to crawl from beyond seedURL: every
+// Allow/Disallow path robots.txt lists (when useRobots), and every page a
+// sitemap.xml lists (when useSitemap), expanding sitemap index files
+// (and any sitemaps robots.txt pointed at) recursively.
+func discoverSeeds(ctx context.Context, pool *crawler.Pool, seedURL string, opts scanOptions, useRobots bool, useSitemap bool) []string {
+	var pageSeeds []string
+	var sitemapQueue []string
+
+	if useRobots {
+		robotsURL := urlJoin(seedURL, "/robots.txt")
+		result := <-pool.Fetch(ctx, 1, []string{robotsURL})
+		if result.Err != nil {
+			debugLog(opts.debug, "Debug: Failed to get %s: %v\n", robotsURL, result.Err)
+		} else {
+			sitemaps, paths := discover.ParseRobots(result.Body)
+			for _, s := range sitemaps {
+				sitemapQueue = append(sitemapQueue, urlJoin(seedURL, s))
+			}
+			for _, p := range paths {
+				pageSeeds = append(pageSeeds, urlJoin(seedURL, p))
+			}
+		}
+	}
+
+	if useSitemap {
+		sitemapQueue = append(sitemapQueue, urlJoin(seedURL, "/sitemap.xml"))
+	}
+
+	visitedSitemaps := make(map[string]bool)
+	for len(sitemapQueue) > 0 {
+		sitemapURL := sitemapQueue[0]
+		sitemapQueue = sitemapQueue[1:]
+		if visitedSitemaps[sitemapURL] {
+			continue
+		}
+		visitedSitemaps[sitemapURL] = true
+
+		result := <-pool.Fetch(ctx, 1, []string{sitemapURL})
+		if result.Err != nil {
+			debugLog(opts.debug, "Debug: Failed to get sitemap %s: %v\n", sitemapURL, result.Err)
+			continue
+		}
+
+		pages, nested, err := discover.ParseSitemap(result.Body)
+		if err != nil {
+			debugLog(opts.debug, "Debug: Failed to parse sitemap %s: %v\n", sitemapURL, err)
+			continue
+		}
+		pageSeeds = append(pageSeeds, pages...)
+		sitemapQueue = append(sitemapQueue, nested...)
+	}
+
+	return pageSeeds
+}
+
+// discoverLinks fetches pageURL and returns every URL reachable from it: its
+// in-page anchors, and URL-like string literals pulled out of any JS it
+// references (the same trick recon tools use to find endpoints a bundler
+// only reveals at runtime). URLs are resolved against the URL they were
+// found on but are not filtered by scope; the caller does that.
+func discoverLinks(ctx context.Context, pool *crawler.Pool, pageURL string, opts scanOptions) []string {
+	pageResult := <-pool.Fetch(ctx, 1, []string{pageURL})
+	if pageResult.Err != nil {
+		debugLog(opts.debug, "Debug: Failed to get %s: %v\n", pageURL, pageResult.Err)
+		return nil
+	}
+
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(string(pageResult.Body)))
+	if err != nil {
+		debugLog(opts.debug, "Debug: Failed to parse %s: %v\n", pageURL, err)
+		return nil
+	}
+
+	var links []string
+	doc.Find("a[href]").Each(func(i int, s *goquery.Selection) {
+		href, _ := s.Attr("href")
+		if href == "" || strings.HasPrefix(href, "javascript:") || strings.HasPrefix(href, "mailto:") {
+			return
+		}
+		links = append(links, urlJoin(pageURL, href))
 	})
+
+	var jsURLs []string
+	doc.Find("script").Each(func(i int, s *goquery.Selection) {
+		if src, _ := s.Attr("src"); src != "" {
+			jsURLs = append(jsURLs, urlJoin(pageURL, src))
+		}
+	})
+
+	for result := range pool.Fetch(ctx, opts.concurrency, jsURLs) {
+		if result.Err != nil {
+			debugLog(opts.debug, "Debug: Failed to get %s: %v\n", result.URL, result.Err)
+			continue
+		}
+		for _, found := range discover.ExtractJSURLs(string(result.Body)) {
+			links = append(links, urlJoin(result.URL, found))
+		}
+	}
+
+	return links
+}
+
+// processJS runs every scraper against a single fetched JS file's content,
+// attributing matches back to their pre-bundle origin when a source map is
+// available, and recurses the same scrapers into each reconstructed
+// original source.
+func processJS(ctx context.Context, pool *crawler.Pool, registry *scraper.Registry, reporter report.Reporter, sourceURL string, jsURL string, cleanJsContent string, opts scanOptions) {
+	sm := fetchSourceMap(ctx, pool, jsURL, cleanJsContent, opts.debug)
+
+	runScrapers(reporter, registry, sourceURL, jsURL, cleanJsContent, opts, sm)
+
+	if sm == nil {
+		return
+	}
+
+	if opts.dumpSourcesDir != "" {
+		if _, err := sm.WriteSources(opts.dumpSourcesDir); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to dump sources for %s: %v\n", jsURL, err)
+		}
+	}
+
+	for i := range sm.Sources {
+		content, ok := sm.SourceContent(i)
+		if !ok {
+			continue
+		}
+		runScrapers(reporter, registry, sourceURL, sm.SourcePath(i), removeANSI(content), opts, nil)
+	}
+}
+
+// fetchSourceMap looks for a sourceMappingURL comment on any line of
+// cleanJsContent (not just the last) and, failing that, probes
+// "<jsURL>.map" as a fallback, returning the first map that fetches and
+// parses successfully, or nil if none do. Failures are only logged under
+// -debug: the ".map" probe is speculative (most JS files have no source
+// map at all), so logging it unconditionally would spam stderr on every
+// ordinary scan.
+func fetchSourceMap(ctx context.Context, pool *crawler.Pool, jsURL string, cleanJsContent string, debug bool) *sourcemap.Map {
+	candidates := sourcemap.FindDirectives(cleanJsContent)
+	candidates = append(candidates, jsURL+".map")
+
+	for _, candidate := range candidates {
+		mapURL := urlJoin(jsURL, candidate)
+		debugLog(debug, "Debug: Fetching source map: %s\n", mapURL)
+
+		mapResult := <-pool.Fetch(ctx, 1, []string{mapURL})
+		if mapResult.Err != nil {
+			debugLog(debug, "Debug: Failed to get %s: %v\n", mapURL, mapResult.Err)
+			continue
+		}
+
+		sm, err := sourcemap.Parse(mapResult.Body)
+		if err != nil {
+			debugLog(debug, "Debug: Failed to parse source map %s: %v\n", mapURL, err)
+			continue
+		}
+		return sm
+	}
+	return nil
 }
 
 func main() {
-	http.DefaultTransport.(*http.Transport).TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
-	fmt.Print(banner)
+	fmt.Fprint(os.Stderr, banner)
 
 	url := flag.String("u", "", "URL of the website to scan")
 	list := flag.String("l", "", "Path to a file containing a list of URLs to scan")
 	output := flag.String("o", "", "Path to output file")
 	debug := flag.Bool("debug", false, "Print debugging statements")
+
+	concurrency := flag.Int("c", 10, "Number of concurrent workers used to fetch JS files")
+	flag.IntVar(concurrency, "concurrency", 10, "Number of concurrent workers used to fetch JS files (alias of -c)")
+	rate := flag.Float64("rate", 0, "Max requests per second per host (0 = unlimited)")
+	timeout := flag.Duration("timeout", 10*time.Second, "HTTP request timeout")
+	scrapersPath := flag.String("scrapers", "", "Path to a YAML/JSON file of additional scraper rules")
+	outputFormat := flag.String("of", report.FormatText, "Output format: text, json, jsonl, or sarif")
+	flag.StringVar(outputFormat, "output-format", report.FormatText, "Output format: text, json, jsonl, or sarif (alias of -of)")
+	dumpSources := flag.String("dump-sources", "", "Directory to reconstruct original sources into, when a JS file's source map has them")
+	pathFilter := flag.String("path-filter", "", "Only scan JS URLs containing this substring (empty = scan every .js URL found)")
+	renderMode := flag.Bool("render", false, "Load the page in headless Chromium and scan every JS resource it loads, catching scripts a SPA injects at runtime")
+	renderTimeout := flag.Duration("render-timeout", 30*time.Second, "Max time to wait for a -render page to finish loading")
+	var scopeRules stringList
+	flag.Var(&scopeRules, "scope", "Allow/deny host glob widening or narrowing the automatic eTLD+1 scope rule, e.g. +*.cdn.example.com or -ads.example.com (repeatable)")
+	robotsMode := flag.Bool("robots", false, "Seed additional pages to scan from the target's robots.txt (Sitemap: and Allow/Disallow entries)")
+	sitemapMode := flag.Bool("sitemap", false, "Seed additional pages to scan from the target's sitemap.xml, expanding sitemap index files")
+	maxDepth := flag.Int("depth", 0, "Follow in-page anchors and JS-referenced URLs up to this many hops beyond each seed URL, bounded by -scope (0 = don't crawl)")
 	flag.Parse()
 
+	var parsedScopeRules []scope.Rule
+	for _, raw := range scopeRules {
+		rule, err := scope.ParseRule(raw)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return
+		}
+		parsedScopeRules = append(parsedScopeRules, rule)
+	}
+
+	registry := scraper.NewRegistry()
+	for _, s := range scraper.Builtins() {
+		registry.Register(s)
+	}
+	if *scrapersPath != "" {
+		userScrapers, err := scraper.LoadConfig(*scrapersPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to load scrapers config %s: %v\n", *scrapersPath, err)
+			return
+		}
+		for _, s := range userScrapers {
+			registry.Register(s)
+		}
+	}
+
+	// outputFile stays a nil io.Writer (not a non-nil interface wrapping a
+	// nil *os.File) when -o isn't set, so report.New's nil checks work.
+	var outputFile io.Writer
 	if *output != "" {
 		file, err := os.Create(*output)
 		if err != nil {
-			fmt.Printf("Failed to create %s: %v\n", *output, err)
+			fmt.Fprintf(os.Stderr, "Failed to create %s: %v\n", *output, err)
 			return
 		}
 		defer file.Close()
+		outputFile = file
+	}
 
-		outputFileWriter = bufio.NewWriter(file)
+	reporter, err := report.New(*outputFormat, outputFile)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return
+	}
+	defer reporter.Close()
+
+	ctx := context.Background()
+	pool := crawler.NewPool(*timeout, *rate)
+
+	opts := scanOptions{
+		concurrency:    *concurrency,
+		debug:          *debug,
+		dumpSourcesDir: *dumpSources,
+		pathFilter:     *pathFilter,
+		renderTimeout:  *renderTimeout,
+		scope:          scope.New(parsedScopeRules),
+	}
+	if *renderMode {
+		opts.renderDriver = render.NewChromeDriver()
 	}
 
 	if *url != "" {
-		scrapeJSFiles(*url, *debug)
+		scanSeed(ctx, pool, registry, reporter, *url, opts, *robotsMode, *sitemapMode, *maxDepth)
 	} else if *list != "" {
 		file, err := os.Open(*list)
 		if err != nil {
-			fmt.Printf("Failed to open %s: %v\n", *list, err)
+			fmt.Fprintf(os.Stderr, "Failed to open %s: %v\n", *list, err)
 			return
 		}
 		defer file.Close()
@@ -233,29 +526,26 @@ func main() {
 		scanner := bufio.NewScanner(file)
 		for scanner.Scan() {
 			cleanedInput := removeANSI(scanner.Text()) // Remove color codes
-			scrapeJSFiles(cleanedInput, *debug)        // Here you don't need to split the input anymore.
+			scanSeed(ctx, pool, registry, reporter, cleanedInput, opts, *robotsMode, *sitemapMode, *maxDepth)
 		}
 		if err := scanner.Err(); err != nil {
-			fmt.Printf("Error reading file %s: %v\n", *list, err)
+			fmt.Fprintf(os.Stderr, "Error reading file %s: %v\n", *list, err)
 		}
 	} else if info, err := os.Stdin.Stat(); err == nil && info.Mode()&os.ModeCharDevice == 0 {
 		scanner := bufio.NewScanner(os.Stdin)
 		for scanner.Scan() {
-			fmt.Println(scanner.Text())                // print the input before processing
+			fmt.Fprintln(os.Stderr, scanner.Text())     // echo the input before processing
 			cleanedInput := removeANSI(scanner.Text()) // Remove color codes
-			if outputFileWriter != nil {
-				_, _ = outputFileWriter.WriteString(cleanedInput + "\n")
-				_ = outputFileWriter.Flush()
-			}
+			reporter.Raw(cleanedInput)
 			urlParts := strings.Split(cleanedInput, " ")
 			if len(urlParts) > 3 {
-				scrapeJSFiles(urlParts[3], *debug)
+				scanSeed(ctx, pool, registry, reporter, urlParts[3], opts, *robotsMode, *sitemapMode, *maxDepth)
 			} else {
-				fmt.Println("Invalid input:", cleanedInput)
+				fmt.Fprintln(os.Stderr, "Invalid input:", cleanedInput)
 			}
 		}
 		if err := scanner.Err(); err != nil {
-			fmt.Printf("Error reading from stdin: %v\n", err)
+			fmt.Fprintf(os.Stderr, "Error reading from stdin: %v\n", err)
 		}
 	}
 }
@@ -0,0 +1,161 @@
+package crawler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestFetchReturnsBody(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("hello"))
+	}))
+	defer srv.Close()
+
+	p := NewPool(time.Second, 0)
+	results := collect(p.Fetch(context.Background(), 2, []string{srv.URL}))
+
+	if len(results) != 1 {
+		t.Fatalf("got %d results, want 1", len(results))
+	}
+	if results[0].Err != nil {
+		t.Fatalf("unexpected error: %v", results[0].Err)
+	}
+	if string(results[0].Body) != "hello" {
+		t.Errorf("Body = %q, want %q", results[0].Body, "hello")
+	}
+}
+
+func TestFetchRetriesServerErrors(t *testing.T) {
+	var attempts int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte("ok"))
+	}))
+	defer srv.Close()
+
+	p := NewPool(time.Second, 0)
+	results := collect(p.Fetch(context.Background(), 1, []string{srv.URL}))
+
+	if len(results) != 1 {
+		t.Fatalf("got %d results, want 1", len(results))
+	}
+	if results[0].Err != nil {
+		t.Fatalf("expected eventual success, got error: %v", results[0].Err)
+	}
+	if got := atomic.LoadInt32(&attempts); got != 3 {
+		t.Errorf("attempts = %d, want 3", got)
+	}
+}
+
+func TestFetchGivesUpAfterMaxRetries(t *testing.T) {
+	var attempts int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	p := NewPool(time.Second, 0)
+	results := collect(p.Fetch(context.Background(), 1, []string{srv.URL}))
+
+	if len(results) != 1 {
+		t.Fatalf("got %d results, want 1", len(results))
+	}
+	if results[0].Err == nil {
+		t.Fatal("expected an error after exhausting retries")
+	}
+	if got := atomic.LoadInt32(&attempts); got != int32(p.maxRetries+1) {
+		t.Errorf("attempts = %d, want %d", got, p.maxRetries+1)
+	}
+}
+
+func TestFetchMultipleURLs(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.URL.Path))
+	}))
+	defer srv.Close()
+
+	urls := []string{srv.URL + "/a", srv.URL + "/b", srv.URL + "/c"}
+	p := NewPool(time.Second, 0)
+	results := collect(p.Fetch(context.Background(), 3, urls))
+
+	if len(results) != len(urls) {
+		t.Fatalf("got %d results, want %d", len(results), len(urls))
+	}
+	for _, r := range results {
+		if r.Err != nil {
+			t.Errorf("unexpected error for %s: %v", r.URL, r.Err)
+		}
+	}
+}
+
+func TestFetchHonorsContextCancellation(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	p := NewPool(time.Second, 0)
+	results := collect(p.Fetch(ctx, 1, []string{srv.URL}))
+
+	if len(results) != 0 {
+		t.Fatalf("got %d results with a pre-canceled context, want 0", len(results))
+	}
+}
+
+func TestHostLimiterSerializesSameHost(t *testing.T) {
+	limiter := newHostLimiter(100) // 10ms interval
+
+	start := time.Now()
+	limiter.wait("example.com")
+	limiter.wait("example.com")
+	elapsed := time.Since(start)
+
+	if elapsed < 9*time.Millisecond {
+		t.Errorf("second wait() for the same host returned after %v, want at least ~10ms", elapsed)
+	}
+}
+
+func TestHostLimiterDisabledAtZeroRate(t *testing.T) {
+	limiter := newHostLimiter(0)
+
+	start := time.Now()
+	limiter.wait("example.com")
+	limiter.wait("example.com")
+	elapsed := time.Since(start)
+
+	if elapsed > 5*time.Millisecond {
+		t.Errorf("wait() with rate 0 took %v, want effectively instant", elapsed)
+	}
+}
+
+func TestHostLimiterIndependentHosts(t *testing.T) {
+	limiter := newHostLimiter(10) // 100ms interval
+
+	start := time.Now()
+	limiter.wait("a.example.com")
+	limiter.wait("b.example.com")
+	elapsed := time.Since(start)
+
+	if elapsed > 50*time.Millisecond {
+		t.Errorf("waits for two different hosts took %v, want them to run independently", elapsed)
+	}
+}
+
+func collect(results <-chan Result) []Result {
+	var out []Result
+	for r := range results {
+		out = append(out, r)
+	}
+	return out
+}
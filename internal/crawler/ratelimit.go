@@ -0,0 +1,49 @@
+package crawler
+
+import (
+	"sync"
+	"time"
+)
+
+// hostLimiter enforces a simple per-host requests-per-second cap by making
+// callers wait until enough time has elapsed since that host's last request.
+// A rate of 0 disables limiting entirely.
+type hostLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	last     map[string]time.Time
+}
+
+func newHostLimiter(ratePerSecond float64) *hostLimiter {
+	var interval time.Duration
+	if ratePerSecond > 0 {
+		interval = time.Duration(float64(time.Second) / ratePerSecond)
+	}
+
+	return &hostLimiter{
+		interval: interval,
+		last:     make(map[string]time.Time),
+	}
+}
+
+// wait blocks, if necessary, until it is this host's turn to fire another
+// request.
+func (h *hostLimiter) wait(host string) {
+	if h.interval <= 0 {
+		return
+	}
+
+	h.mu.Lock()
+	next := h.last[host].Add(h.interval)
+	now := time.Now()
+	var sleep time.Duration
+	if next.After(now) {
+		sleep = next.Sub(now)
+	}
+	h.last[host] = now.Add(sleep)
+	h.mu.Unlock()
+
+	if sleep > 0 {
+		time.Sleep(sleep)
+	}
+}
@@ -0,0 +1,93 @@
+// Package discover finds additional pages worth scanning beyond the seed
+// URL a user hands kneejerk, by parsing robots.txt and sitemap.xml and by
+// pulling URL-like string literals out of JS bodies (a common recon trick
+// for finding endpoints a bundler only reveals at runtime). It only parses
+// bytes; fetching and resolving the URLs it returns is the caller's job.
+package discover
+
+import (
+	"bufio"
+	"encoding/xml"
+	"regexp"
+	"strings"
+)
+
+// ParseRobots extracts every Sitemap: URL and Allow/Disallow path from a
+// robots.txt body. Paths are returned relative to the site root; callers
+// are expected to resolve them against the host that served the file.
+func ParseRobots(body []byte) (sitemaps []string, paths []string) {
+	scanner := bufio.NewScanner(strings.NewReader(string(body)))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case hasFieldPrefix(line, "sitemap:"):
+			if v := strings.TrimSpace(line[len("sitemap:"):]); v != "" {
+				sitemaps = append(sitemaps, v)
+			}
+		case hasFieldPrefix(line, "allow:"):
+			if v := strings.TrimSpace(line[len("allow:"):]); v != "" {
+				paths = append(paths, v)
+			}
+		case hasFieldPrefix(line, "disallow:"):
+			if v := strings.TrimSpace(line[len("disallow:"):]); v != "" {
+				paths = append(paths, v)
+			}
+		}
+	}
+	return sitemaps, paths
+}
+
+// hasFieldPrefix reports whether line starts with field, a robots.txt
+// directive name, matched case-insensitively per the robots.txt spec.
+func hasFieldPrefix(line, field string) bool {
+	return len(line) >= len(field) && strings.EqualFold(line[:len(field)], field)
+}
+
+// sitemapXML mirrors the <url>/<loc> and <sitemap>/<loc> shapes of both a
+// plain sitemap (urlset) and a sitemap index (sitemapindex); encoding/xml
+// matches child elements by name regardless of the document's root
+// element, so one struct decodes either.
+type sitemapXML struct {
+	URLs []struct {
+		Loc string `xml:"loc"`
+	} `xml:"url"`
+	Sitemaps []struct {
+		Loc string `xml:"loc"`
+	} `xml:"sitemap"`
+}
+
+// ParseSitemap extracts page URLs and, separately, any nested sitemap URLs
+// from a sitemap.xml body. Callers should fetch and parse nested sitemaps
+// the same way to fully expand a sitemap index.
+func ParseSitemap(body []byte) (pages []string, sitemaps []string, err error) {
+	var set sitemapXML
+	if err := xml.Unmarshal(body, &set); err != nil {
+		return nil, nil, err
+	}
+	for _, u := range set.URLs {
+		if u.Loc != "" {
+			pages = append(pages, u.Loc)
+		}
+	}
+	for _, s := range set.Sitemaps {
+		if s.Loc != "" {
+			sitemaps = append(sitemaps, s.Loc)
+		}
+	}
+	return pages, sitemaps, nil
+}
+
+// jsURLPattern matches quoted string literals that look like URLs: an
+// absolute http(s) URL, or a root-relative path.
+var jsURLPattern = regexp.MustCompile(`["'](https?://[^"'\s]+|/[a-zA-Z0-9][^"'\s]*)["']`)
+
+// ExtractJSURLs pulls every URL-like string literal out of jsContent.
+// Results are returned as found, with no dedup or scope filtering; callers
+// are expected to resolve and filter them before visiting.
+func ExtractJSURLs(jsContent string) []string {
+	var urls []string
+	for _, m := range jsURLPattern.FindAllStringSubmatch(jsContent, -1) {
+		urls = append(urls, m[1])
+	}
+	return urls
+}
@@ -0,0 +1,107 @@
+package discover
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseRobots(t *testing.T) {
+	body := []byte(`
+User-agent: *
+Disallow: /admin
+Allow: /admin/public
+Sitemap: https://example.com/sitemap.xml
+SITEMAP: /sitemap-news.xml
+
+# a comment, and blank lines should be ignored
+Disallow:
+`)
+
+	sitemaps, paths := ParseRobots(body)
+
+	wantSitemaps := []string{"https://example.com/sitemap.xml", "/sitemap-news.xml"}
+	if !reflect.DeepEqual(sitemaps, wantSitemaps) {
+		t.Errorf("sitemaps = %v, want %v", sitemaps, wantSitemaps)
+	}
+
+	wantPaths := []string{"/admin", "/admin/public"}
+	if !reflect.DeepEqual(paths, wantPaths) {
+		t.Errorf("paths = %v, want %v", paths, wantPaths)
+	}
+}
+
+func TestParseRobotsEmptyBody(t *testing.T) {
+	sitemaps, paths := ParseRobots([]byte(""))
+	if sitemaps != nil || paths != nil {
+		t.Errorf("got sitemaps=%v paths=%v, want both nil for an empty body", sitemaps, paths)
+	}
+}
+
+func TestParseSitemapURLSet(t *testing.T) {
+	body := []byte(`<?xml version="1.0" encoding="UTF-8"?>
+<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">
+  <url><loc>https://example.com/</loc></url>
+  <url><loc>https://example.com/about</loc></url>
+</urlset>`)
+
+	pages, sitemaps, err := ParseSitemap(body)
+	if err != nil {
+		t.Fatalf("ParseSitemap() error = %v", err)
+	}
+	if sitemaps != nil {
+		t.Errorf("sitemaps = %v, want nil for a plain urlset", sitemaps)
+	}
+
+	want := []string{"https://example.com/", "https://example.com/about"}
+	if !reflect.DeepEqual(pages, want) {
+		t.Errorf("pages = %v, want %v", pages, want)
+	}
+}
+
+func TestParseSitemapIndex(t *testing.T) {
+	body := []byte(`<?xml version="1.0" encoding="UTF-8"?>
+<sitemapindex xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">
+  <sitemap><loc>https://example.com/sitemap-1.xml</loc></sitemap>
+  <sitemap><loc>https://example.com/sitemap-2.xml</loc></sitemap>
+</sitemapindex>`)
+
+	pages, sitemaps, err := ParseSitemap(body)
+	if err != nil {
+		t.Fatalf("ParseSitemap() error = %v", err)
+	}
+	if pages != nil {
+		t.Errorf("pages = %v, want nil for a sitemap index", pages)
+	}
+
+	want := []string{"https://example.com/sitemap-1.xml", "https://example.com/sitemap-2.xml"}
+	if !reflect.DeepEqual(sitemaps, want) {
+		t.Errorf("sitemaps = %v, want %v", sitemaps, want)
+	}
+}
+
+func TestParseSitemapInvalidXML(t *testing.T) {
+	if _, _, err := ParseSitemap([]byte("not xml")); err == nil {
+		t.Fatal("ParseSitemap() error = nil, want an error for invalid XML")
+	}
+}
+
+func TestExtractJSURLs(t *testing.T) {
+	content := `
+		fetch("https://api.example.com/v1/users");
+		var base = '/api/v2/orders';
+		const x = "not a url";
+		const y = '/';
+	`
+
+	got := ExtractJSURLs(content)
+	want := []string{"https://api.example.com/v1/users", "/api/v2/orders"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ExtractJSURLs() = %v, want %v", got, want)
+	}
+}
+
+func TestExtractJSURLsNoMatches(t *testing.T) {
+	if got := ExtractJSURLs(`console.log("hello world")`); got != nil {
+		t.Errorf("ExtractJSURLs() = %v, want nil", got)
+	}
+}
@@ -0,0 +1,132 @@
+package render
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/chromedp/cdproto/network"
+	"github.com/chromedp/chromedp"
+)
+
+// ChromeDriver renders pages in headless Chromium via chromedp, capturing
+// every JS resource loaded through the Chrome DevTools Protocol's
+// Network.responseReceived event.
+type ChromeDriver struct {
+	// QuietPeriod is how long the page must go without a new network
+	// response before it's considered to have finished loading.
+	QuietPeriod time.Duration
+}
+
+// NewChromeDriver returns a ChromeDriver with kneejerk's default settle
+// detection.
+func NewChromeDriver() *ChromeDriver {
+	return &ChromeDriver{QuietPeriod: 1 * time.Second}
+}
+
+type chromeResponse struct {
+	requestID network.RequestID
+	url       string
+}
+
+// Render loads pageURL in headless Chromium, waits for network activity to
+// go quiet (bounded by ctx's deadline), and returns the body of every JS
+// resource observed loading.
+func (d *ChromeDriver) Render(ctx context.Context, pageURL string) ([]Resource, error) {
+	allocCtx, cancelAlloc := chromedp.NewExecAllocator(ctx,
+		append(chromedp.DefaultExecAllocatorOptions[:], chromedp.NoSandbox)...)
+	defer cancelAlloc()
+
+	browserCtx, cancelBrowser := chromedp.NewContext(allocCtx)
+	defer cancelBrowser()
+
+	var mu sync.Mutex
+	var jsResponses []chromeResponse
+	lastActivity := time.Now()
+
+	chromedp.ListenTarget(browserCtx, func(ev interface{}) {
+		e, ok := ev.(*network.EventResponseReceived)
+		if !ok || e.Response == nil {
+			return
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		lastActivity = time.Now()
+		if isJavaScript(e.Response.MimeType, e.Response.URL) {
+			jsResponses = append(jsResponses, chromeResponse{requestID: e.RequestID, url: e.Response.URL})
+		}
+	})
+
+	if err := chromedp.Run(browserCtx, network.Enable(), chromedp.Navigate(pageURL)); err != nil {
+		return nil, fmt.Errorf("render %s: %w", pageURL, err)
+	}
+
+	// A page with continuous background traffic (analytics beacons,
+	// polling, websockets) may never go quiet before ctx's deadline; treat
+	// that as "stop waiting", not failure, and scan whatever JS we've
+	// captured so far rather than discarding it.
+	d.waitForQuiet(ctx, &mu, &lastActivity)
+
+	mu.Lock()
+	responses := append([]chromeResponse(nil), jsResponses...)
+	mu.Unlock()
+
+	resources := make([]Resource, 0, len(responses))
+	for _, r := range responses {
+		var body []byte
+		err := chromedp.Run(browserCtx, chromedp.ActionFunc(func(ctx context.Context) error {
+			b, err := network.GetResponseBody(r.requestID).Do(ctx)
+			if err != nil {
+				return err
+			}
+			body = b
+			return nil
+		}))
+		if err != nil {
+			// The response body may no longer be available (e.g. evicted
+			// from Chromium's cache); skip it rather than fail the whole
+			// render.
+			continue
+		}
+		resources = append(resources, Resource{URL: r.url, Body: body})
+	}
+
+	return resources, nil
+}
+
+// waitForQuiet blocks until no network response has arrived for at least
+// QuietPeriod, or ctx is done (in which case it gives up waiting, leaving
+// whatever was captured so far for the caller to use).
+func (d *ChromeDriver) waitForQuiet(ctx context.Context, mu *sync.Mutex, lastActivity *time.Time) {
+	for {
+		mu.Lock()
+		quiet := time.Since(*lastActivity) >= d.QuietPeriod
+		mu.Unlock()
+		if quiet {
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+}
+
+// isJavaScript reports whether a response looks like a JS resource, by
+// MIME type (how the browser actually classified it) or file extension
+// (a fallback for responses served with a generic content type).
+func isJavaScript(mimeType, url string) bool {
+	if strings.Contains(mimeType, "javascript") || strings.Contains(mimeType, "ecmascript") {
+		return true
+	}
+	path := url
+	if idx := strings.IndexAny(path, "?#"); idx != -1 {
+		path = path[:idx]
+	}
+	return strings.HasSuffix(path, ".js")
+}
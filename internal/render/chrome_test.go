@@ -0,0 +1,71 @@
+package render
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestIsJavaScript(t *testing.T) {
+	tests := []struct {
+		name     string
+		mimeType string
+		url      string
+		want     bool
+	}{
+		{name: "application/javascript", mimeType: "application/javascript", url: "https://example.com/app.bundle", want: true},
+		{name: "text/ecmascript", mimeType: "text/ecmascript", url: "https://example.com/app.bundle", want: true},
+		{name: "wrong mime falls back to .js extension", mimeType: "text/plain", url: "https://example.com/app.js", want: true},
+		{name: ".js with query string", mimeType: "text/plain", url: "https://example.com/app.js?v=2", want: true},
+		{name: ".js with fragment", mimeType: "text/plain", url: "https://example.com/app.js#chunk", want: true},
+		{name: "neither mime nor extension matches", mimeType: "text/html", url: "https://example.com/index.html", want: false},
+		{name: "mime and extension both wrong", mimeType: "image/png", url: "https://example.com/logo.png", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isJavaScript(tt.mimeType, tt.url); got != tt.want {
+				t.Errorf("isJavaScript(%q, %q) = %v, want %v", tt.mimeType, tt.url, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWaitForQuietReturnsOnceQuietPeriodElapses(t *testing.T) {
+	d := &ChromeDriver{QuietPeriod: 20 * time.Millisecond}
+	var mu sync.Mutex
+	lastActivity := time.Now()
+
+	start := time.Now()
+	d.waitForQuiet(context.Background(), &mu, &lastActivity)
+	elapsed := time.Since(start)
+
+	if elapsed < d.QuietPeriod {
+		t.Errorf("waitForQuiet returned after %v, want at least the quiet period (%v)", elapsed, d.QuietPeriod)
+	}
+}
+
+func TestWaitForQuietStopsOnContextCancellation(t *testing.T) {
+	d := &ChromeDriver{QuietPeriod: time.Hour}
+	var mu sync.Mutex
+	lastActivity := time.Now()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	d.waitForQuiet(ctx, &mu, &lastActivity)
+	elapsed := time.Since(start)
+
+	if elapsed > time.Second {
+		t.Errorf("waitForQuiet took %v, want it to give up once ctx is done rather than waiting out the quiet period", elapsed)
+	}
+}
+
+func TestNewChromeDriverDefaultQuietPeriod(t *testing.T) {
+	d := NewChromeDriver()
+	if d.QuietPeriod != 1*time.Second {
+		t.Errorf("QuietPeriod = %v, want 1s", d.QuietPeriod)
+	}
+}
@@ -0,0 +1,21 @@
+// Package render drives a headless browser to discover JS resources a page
+// loads at runtime (React/Vue/Angular SPAs inject <script> tags after the
+// initial HTML is parsed, so a plain HTTP fetch never sees them), capturing
+// each resource's body directly off the network so kneejerk doesn't need a
+// second fetch to scan it.
+package render
+
+import "context"
+
+// Resource is a single JS resource observed loading while a page rendered.
+type Resource struct {
+	URL  string
+	Body []byte
+}
+
+// Driver renders pageURL in a browser, waits for the page to settle, and
+// returns every JS resource it loaded. Implementations are expected to
+// honor ctx's deadline/cancellation.
+type Driver interface {
+	Render(ctx context.Context, pageURL string) ([]Resource, error)
+}
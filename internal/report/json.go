@@ -0,0 +1,34 @@
+package report
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+)
+
+// jsonReporter buffers every finding and emits a single JSON array on
+// Close, so the output is one valid JSON document.
+type jsonReporter struct {
+	w        io.Writer
+	findings []record
+}
+
+func (j *jsonReporter) Report(f Finding) {
+	j.findings = append(j.findings, toRecord(f))
+}
+
+func (j *jsonReporter) Raw(string) {}
+
+func (j *jsonReporter) Close() error {
+	w := j.w
+	if w == nil {
+		w = os.Stdout
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if j.findings == nil {
+		j.findings = []record{}
+	}
+	return enc.Encode(j.findings)
+}
@@ -0,0 +1,60 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func TestJSONReporterEmitsArray(t *testing.T) {
+	var buf bytes.Buffer
+	r := &jsonReporter{w: &buf}
+
+	r.Report(Finding{ScraperID: "jwt", Output: "secret", Severity: "high", Match: "abc"})
+	r.Report(Finding{ScraperID: "env-var", Output: "env-var", Severity: "info", Match: "def"})
+	if err := r.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	var records []record
+	if err := json.Unmarshal(buf.Bytes(), &records); err != nil {
+		t.Fatalf("output is not a valid JSON array: %v\n%s", err, buf.String())
+	}
+	if len(records) != 2 {
+		t.Fatalf("got %d records, want 2", len(records))
+	}
+	if records[0].ScraperID != "jwt" || records[1].ScraperID != "env-var" {
+		t.Errorf("records = %+v, want findings in report order", records)
+	}
+}
+
+func TestJSONReporterEmptyIsEmptyArray(t *testing.T) {
+	var buf bytes.Buffer
+	r := &jsonReporter{w: &buf}
+
+	if err := r.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	var records []record
+	if err := json.Unmarshal(buf.Bytes(), &records); err != nil {
+		t.Fatalf("output is not valid JSON: %v\n%s", err, buf.String())
+	}
+	if records == nil || len(records) != 0 {
+		t.Errorf("records = %v, want an empty (not null) array", records)
+	}
+}
+
+func TestJSONReporterIncludesFingerprint(t *testing.T) {
+	var buf bytes.Buffer
+	r := &jsonReporter{w: &buf}
+	f := Finding{ScraperID: "jwt", Match: "abc"}
+	r.Report(f)
+	r.Close()
+
+	var records []record
+	json.Unmarshal(buf.Bytes(), &records)
+	if len(records) != 1 || records[0].Fingerprint != f.Fingerprint() {
+		t.Errorf("records = %+v, want Fingerprint %q", records, f.Fingerprint())
+	}
+}
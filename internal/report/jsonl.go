@@ -0,0 +1,71 @@
+package report
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+)
+
+// record is the structured shape shared by the json and jsonl formats.
+type record struct {
+	TemplateID   string `json:"templateId"`
+	ScraperID    string `json:"scraperId"`
+	Output       string `json:"outputType"`
+	Severity     string `json:"severity"`
+	SourceURL    string `json:"sourceUrl"`
+	JSURL        string `json:"jsUrl"`
+	Method       string `json:"method,omitempty"`
+	Endpoint     string `json:"endpoint,omitempty"`
+	Match        string `json:"match"`
+	Offset       int    `json:"offset"`
+	OriginalFile string `json:"originalFile,omitempty"`
+	OriginalLine int    `json:"originalLine,omitempty"`
+	Fingerprint  string `json:"fingerprint"`
+}
+
+func toRecord(f Finding) record {
+	return record{
+		TemplateID:   f.TemplateID,
+		ScraperID:    f.ScraperID,
+		Output:       f.Output,
+		Severity:     f.Severity,
+		SourceURL:    f.SourceURL,
+		JSURL:        f.JSURL,
+		Method:       f.Method,
+		Endpoint:     f.Endpoint,
+		Match:        f.Match,
+		Offset:       f.Offset,
+		OriginalFile: f.OriginalFile,
+		OriginalLine: f.OriginalLine,
+		Fingerprint:  f.Fingerprint(),
+	}
+}
+
+// jsonlReporter writes one JSON object per finding, newline-delimited, as
+// it's discovered.
+type jsonlReporter struct {
+	w   io.Writer
+	enc *json.Encoder
+}
+
+func (j *jsonlReporter) target() io.Writer {
+	if j.w == nil {
+		return os.Stdout
+	}
+	return j.w
+}
+
+func (j *jsonlReporter) encoder() *json.Encoder {
+	if j.enc == nil {
+		j.enc = json.NewEncoder(j.target())
+	}
+	return j.enc
+}
+
+func (j *jsonlReporter) Report(f Finding) {
+	_ = j.encoder().Encode(toRecord(f))
+}
+
+func (j *jsonlReporter) Raw(string) {}
+
+func (j *jsonlReporter) Close() error { return nil }
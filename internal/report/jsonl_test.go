@@ -0,0 +1,54 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestJSONLReporterOneObjectPerLine(t *testing.T) {
+	var buf bytes.Buffer
+	r := &jsonlReporter{w: &buf}
+
+	r.Report(Finding{ScraperID: "jwt", Match: "abc"})
+	r.Report(Finding{ScraperID: "env-var", Match: "def"})
+	if err := r.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d lines, want 2: %q", len(lines), buf.String())
+	}
+	for i, line := range lines {
+		var rec record
+		if err := json.Unmarshal([]byte(line), &rec); err != nil {
+			t.Fatalf("line %d is not valid JSON: %v (%q)", i, err, line)
+		}
+	}
+}
+
+func TestJSONLReporterWritesEachFindingImmediately(t *testing.T) {
+	// Unlike jsonReporter, jsonl shouldn't need Close to flush a finding.
+	var buf bytes.Buffer
+	r := &jsonlReporter{w: &buf}
+
+	r.Report(Finding{ScraperID: "jwt", Match: "abc"})
+
+	if buf.Len() == 0 {
+		t.Error("nothing was written to w before Close was called")
+	}
+}
+
+func TestJSONLReporterEmptyProducesNoOutput(t *testing.T) {
+	var buf bytes.Buffer
+	r := &jsonlReporter{w: &buf}
+
+	if err := r.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("buf = %q, want empty output when nothing was reported", buf.String())
+	}
+}
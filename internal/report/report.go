@@ -0,0 +1,81 @@
+// Package report turns scraper findings into kneejerk's output formats:
+// colored text for a terminal, or structured json/jsonl/sarif for feeding
+// into CI dashboards and code-scanning UIs.
+package report
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+)
+
+// Finding is a single reportable result, carrying enough detail to dedup it
+// and to trace it back to where it was found.
+type Finding struct {
+	TemplateID string
+	ScraperID  string
+	Output     string
+	Severity   string
+	SourceURL  string
+	JSURL      string
+	Method     string
+	Endpoint   string
+	Match      string
+	Offset     int
+	// OriginalFile and OriginalLine attribute a match found in a bundled
+	// JS file back to the pre-bundle source it came from, via a source
+	// map's VLQ mappings. Both are empty/zero when no map was available
+	// or the offset fell outside its mappings.
+	OriginalFile string
+	OriginalLine int
+}
+
+// Fingerprint returns a stable hash identifying this finding, suitable for
+// cross-run dedup.
+func (f Finding) Fingerprint() string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s|%s|%s|%s|%s|%s", f.ScraperID, f.JSURL, f.Method, f.Endpoint, f.Match, f.Output)
+	return hex.EncodeToString(h.Sum(nil))[:16]
+}
+
+// Reporter receives findings as they're discovered and renders them in a
+// specific output format.
+type Reporter interface {
+	// Report renders a single finding.
+	Report(f Finding)
+	// Raw renders a line that isn't a scraper finding (e.g. echoed stdin
+	// input). Formats that can't represent a raw line ignore it.
+	Raw(line string)
+	// Close flushes any buffered output and finalizes the format (e.g.
+	// closing a JSON array or emitting a SARIF log envelope).
+	Close() error
+}
+
+// Format names accepted by New.
+const (
+	FormatText  = "text"
+	FormatJSON  = "json"
+	FormatJSONL = "jsonl"
+	FormatSARIF = "sarif"
+)
+
+// New builds a Reporter for the given format. out is where the format's
+// primary output is written: for "text" that's always the colored stdout
+// summary, with out (if non-nil) additionally receiving the plain-text
+// copy; for the structured formats out is the sole destination, defaulting
+// to stdout when nil, so piping `kneejerk -of jsonl` yields clean output.
+func New(format string, out io.Writer) (Reporter, error) {
+	switch format {
+	case "", FormatText:
+		return &textReporter{w: out}, nil
+	case FormatJSON:
+		return &jsonReporter{w: out}, nil
+	case FormatJSONL:
+		return &jsonlReporter{w: out}, nil
+	case FormatSARIF:
+		return &sarifReporter{w: out}, nil
+	default:
+		return nil, fmt.Errorf("unknown output format %q", format)
+	}
+}
@@ -0,0 +1,67 @@
+package report
+
+import "testing"
+
+func TestNewFormats(t *testing.T) {
+	tests := []struct {
+		name    string
+		format  string
+		check   func(Reporter) bool
+		wantErr bool
+	}{
+		{name: "empty defaults to text", format: "", check: func(r Reporter) bool { _, ok := r.(*textReporter); return ok }},
+		{name: "text", format: FormatText, check: func(r Reporter) bool { _, ok := r.(*textReporter); return ok }},
+		{name: "json", format: FormatJSON, check: func(r Reporter) bool { _, ok := r.(*jsonReporter); return ok }},
+		{name: "jsonl", format: FormatJSONL, check: func(r Reporter) bool { _, ok := r.(*jsonlReporter); return ok }},
+		{name: "sarif", format: FormatSARIF, check: func(r Reporter) bool { _, ok := r.(*sarifReporter); return ok }},
+		{name: "unknown", format: "yaml", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := New(tt.format, nil)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("New(%q) error = nil, want an error", tt.format)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("New(%q) unexpected error: %v", tt.format, err)
+			}
+			if !tt.check(r) {
+				t.Errorf("New(%q) returned %T, want a different Reporter implementation", tt.format, r)
+			}
+		})
+	}
+}
+
+func TestFingerprintStableAndDistinct(t *testing.T) {
+	a := Finding{ScraperID: "jwt", JSURL: "https://example.com/app.js", Match: "secret-a"}
+	b := Finding{ScraperID: "jwt", JSURL: "https://example.com/app.js", Match: "secret-a"}
+	c := Finding{ScraperID: "jwt", JSURL: "https://example.com/app.js", Match: "secret-b"}
+
+	if a.Fingerprint() != b.Fingerprint() {
+		t.Error("identical findings produced different fingerprints")
+	}
+	if a.Fingerprint() == c.Fingerprint() {
+		t.Error("findings differing only in Match produced the same fingerprint")
+	}
+	if len(a.Fingerprint()) != 16 {
+		t.Errorf("Fingerprint() length = %d, want 16", len(a.Fingerprint()))
+	}
+}
+
+func TestFingerprintIgnoresOriginalSourceAttribution(t *testing.T) {
+	// Source-map attribution shouldn't change identity: the same secret
+	// found via the same scraper/URL/match is the same finding whether or
+	// not a map happened to resolve an original location for it.
+	withoutMap := Finding{ScraperID: "jwt", JSURL: "https://example.com/app.js", Match: "secret"}
+	withMap := withoutMap
+	withMap.OriginalFile = "src/App.js"
+	withMap.OriginalLine = 12
+
+	if withoutMap.Fingerprint() != withMap.Fingerprint() {
+		t.Error("OriginalFile/OriginalLine affected the fingerprint")
+	}
+}
@@ -0,0 +1,141 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+)
+
+// Minimal SARIF 2.1.0 structures — just enough to carry kneejerk findings
+// into a code-scanning UI.
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name  string      `json:"name"`
+	Rules []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID string `json:"id"`
+}
+
+type sarifResult struct {
+	RuleID              string            `json:"ruleId"`
+	Level               string            `json:"level"`
+	Message             sarifMessage      `json:"message"`
+	Locations           []sarifLocation   `json:"locations"`
+	PartialFingerprints map[string]string `json:"partialFingerprints"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           sarifRegion           `json:"region"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	ByteOffset int `json:"byteOffset"`
+}
+
+// sarifReporter buffers every finding and emits a single SARIF log on
+// Close.
+type sarifReporter struct {
+	w        io.Writer
+	findings []Finding
+	rules    map[string]bool
+}
+
+func (s *sarifReporter) Report(f Finding) {
+	s.findings = append(s.findings, f)
+}
+
+func (s *sarifReporter) Raw(string) {}
+
+func sarifLevel(severity string) string {
+	switch severity {
+	case "high", "medium":
+		return "error"
+	case "low":
+		return "warning"
+	default:
+		return "note"
+	}
+}
+
+func (s *sarifReporter) Close() error {
+	w := s.w
+	if w == nil {
+		w = os.Stdout
+	}
+
+	s.rules = make(map[string]bool)
+	var rules []sarifRule
+	results := make([]sarifResult, 0, len(s.findings))
+	for _, f := range s.findings {
+		if !s.rules[f.ScraperID] {
+			s.rules[f.ScraperID] = true
+			rules = append(rules, sarifRule{ID: f.ScraperID})
+		}
+
+		match := f.Match
+		if f.Output == "api" {
+			match = fmt.Sprintf("%s %s", f.Method, f.Endpoint)
+		}
+
+		message := fmt.Sprintf("%s: %s (found via %s)", f.Output, match, f.SourceURL)
+		if f.OriginalFile != "" {
+			message = fmt.Sprintf("%s [original source: %s:%d]", message, f.OriginalFile, f.OriginalLine)
+		}
+
+		results = append(results, sarifResult{
+			RuleID:  f.ScraperID,
+			Level:   sarifLevel(f.Severity),
+			Message: sarifMessage{Text: message},
+			Locations: []sarifLocation{{
+				PhysicalLocation: sarifPhysicalLocation{
+					ArtifactLocation: sarifArtifactLocation{URI: f.JSURL},
+					Region:           sarifRegion{ByteOffset: f.Offset},
+				},
+			}},
+			PartialFingerprints: map[string]string{"kneejerkFingerprint/v1": f.Fingerprint()},
+		})
+	}
+
+	log := sarifLog{
+		Schema:  "https://raw.githubusercontent.com/oasis-tcs/sarif-spec/master/Schemata/sarif-schema-2.1.0.json",
+		Version: "2.1.0",
+		Runs: []sarifRun{{
+			Tool:    sarifTool{Driver: sarifDriver{Name: "kneejerk", Rules: rules}},
+			Results: results,
+		}},
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(log)
+}
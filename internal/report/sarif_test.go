@@ -0,0 +1,81 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestSARIFReporterProducesValidLog(t *testing.T) {
+	var buf bytes.Buffer
+	r := &sarifReporter{w: &buf}
+
+	r.Report(Finding{ScraperID: "jwt", Output: "secret", Severity: "high", JSURL: "https://example.com/app.js", Match: "abc", Offset: 42})
+	r.Report(Finding{ScraperID: "axios-call", Output: "api", Severity: "low", JSURL: "https://example.com/app.js", Method: "POST", Endpoint: "/v1/signup"})
+	if err := r.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	var log sarifLog
+	if err := json.Unmarshal(buf.Bytes(), &log); err != nil {
+		t.Fatalf("output is not a valid SARIF document: %v\n%s", err, buf.String())
+	}
+
+	if len(log.Runs) != 1 {
+		t.Fatalf("got %d runs, want 1", len(log.Runs))
+	}
+	if got := len(log.Runs[0].Results); got != 2 {
+		t.Fatalf("got %d results, want 2", got)
+	}
+	if got := len(log.Runs[0].Tool.Driver.Rules); got != 2 {
+		t.Errorf("got %d distinct rules, want 2 (one per ScraperID)", got)
+	}
+}
+
+func TestSARIFReporterDedupsRulesByScraperID(t *testing.T) {
+	var buf bytes.Buffer
+	r := &sarifReporter{w: &buf}
+
+	r.Report(Finding{ScraperID: "jwt", Match: "a"})
+	r.Report(Finding{ScraperID: "jwt", Match: "b"})
+	r.Close()
+
+	var log sarifLog
+	json.Unmarshal(buf.Bytes(), &log)
+	if got := len(log.Runs[0].Tool.Driver.Rules); got != 1 {
+		t.Errorf("got %d rules, want 1 (both findings share a ScraperID)", got)
+	}
+}
+
+func TestSARIFLevel(t *testing.T) {
+	tests := []struct {
+		severity string
+		want     string
+	}{
+		{"high", "error"},
+		{"medium", "error"},
+		{"low", "warning"},
+		{"info", "note"},
+		{"", "note"},
+	}
+	for _, tt := range tests {
+		if got := sarifLevel(tt.severity); got != tt.want {
+			t.Errorf("sarifLevel(%q) = %q, want %q", tt.severity, got, tt.want)
+		}
+	}
+}
+
+func TestSARIFReporterFormatsAPIMatchAsMethodAndEndpoint(t *testing.T) {
+	var buf bytes.Buffer
+	r := &sarifReporter{w: &buf}
+	r.Report(Finding{ScraperID: "axios-call", Output: "api", Method: "POST", Endpoint: "/v1/signup", SourceURL: "https://example.com"})
+	r.Close()
+
+	var log sarifLog
+	json.Unmarshal(buf.Bytes(), &log)
+	msg := log.Runs[0].Results[0].Message.Text
+	if want := "POST /v1/signup"; !strings.Contains(msg, want) {
+		t.Errorf("message = %q, want it to contain %q", msg, want)
+	}
+}
@@ -0,0 +1,64 @@
+package report
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/logrusorgru/aurora"
+)
+
+// textReporter reproduces kneejerk's original output: a colored summary
+// line on stdout, plus an uncolored copy appended to w when one was
+// configured (the -o file).
+type textReporter struct {
+	w io.Writer
+}
+
+func (t *textReporter) Report(f Finding) {
+	colored, plain := colorize(f)
+	fmt.Println(colored)
+	if t.w != nil {
+		fmt.Fprintln(t.w, plain)
+	}
+}
+
+func (t *textReporter) Raw(line string) {
+	if t.w != nil {
+		fmt.Fprintln(t.w, line)
+	}
+}
+
+func (t *textReporter) Close() error { return nil }
+
+func colorize(f Finding) (colored string, plain string) {
+	templateIDColored := aurora.BrightGreen(f.TemplateID).String()
+	outputTypeColored := aurora.BrightBlue(f.Output).String()
+
+	var severityColored string
+	switch f.Severity {
+	case "high":
+		severityColored = aurora.Red(f.Severity).String()
+	case "medium":
+		severityColored = aurora.Yellow(f.Severity).String()
+	case "low":
+		severityColored = aurora.Green(f.Severity).String()
+	default:
+		severityColored = aurora.Blue(f.Severity).String()
+	}
+
+	match := f.Match
+	if f.Output == "api" {
+		match = fmt.Sprintf(`"%s", "%s"`, f.Method, f.Endpoint)
+	}
+
+	origin := f.JSURL
+	plainOrigin := f.JSURL
+	if f.OriginalFile != "" {
+		origin = fmt.Sprintf("%s (%s:%d)", origin, aurora.BrightCyan(f.OriginalFile), f.OriginalLine)
+		plainOrigin = fmt.Sprintf("%s (%s:%d)", plainOrigin, f.OriginalFile, f.OriginalLine)
+	}
+
+	colored = fmt.Sprintf("[%s] [%s] [%s] %s [%s]", templateIDColored, outputTypeColored, severityColored, origin, match)
+	plain = fmt.Sprintf("[%s] [%s] [%s] %s [%s]", f.TemplateID, f.Output, f.Severity, plainOrigin, match)
+	return colored, plain
+}
@@ -0,0 +1,42 @@
+package report
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestTextReporterRawWritesToFile(t *testing.T) {
+	var buf bytes.Buffer
+	r := &textReporter{w: &buf}
+
+	r.Raw("GET /index.html 200 http://example.com")
+
+	if got := buf.String(); got != "GET /index.html 200 http://example.com\n" {
+		t.Errorf("buf = %q, want the raw line followed by a newline", got)
+	}
+}
+
+func TestTextReporterRawWithoutFileIsANoop(t *testing.T) {
+	r := &textReporter{}
+	r.Raw("should not panic") // no w configured; just must not panic
+}
+
+func TestColorizeAPIMatchUsesMethodAndEndpoint(t *testing.T) {
+	f := Finding{TemplateID: "axios-call", Output: "api", Severity: "low", Method: "POST", Endpoint: "/v1/signup", JSURL: "https://example.com/app.js"}
+	_, plain := colorize(f)
+
+	want := `[axios-call] [api] [low] https://example.com/app.js ["POST", "/v1/signup"]`
+	if plain != want {
+		t.Errorf("colorize() plain = %q, want %q", plain, want)
+	}
+}
+
+func TestColorizeIncludesOriginalSourceAttribution(t *testing.T) {
+	f := Finding{TemplateID: "jwt", Output: "secret", Severity: "high", JSURL: "https://example.com/app.js", Match: "abc", OriginalFile: "src/App.js", OriginalLine: 12}
+	_, plain := colorize(f)
+
+	if want := "src/App.js:12"; !strings.Contains(plain, want) {
+		t.Errorf("colorize() plain = %q, want it to contain %q", plain, want)
+	}
+}
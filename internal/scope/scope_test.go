@@ -0,0 +1,106 @@
+package scope
+
+import "testing"
+
+func TestSameSite(t *testing.T) {
+	tests := []struct {
+		name string
+		u1   string
+		u2   string
+		want bool
+	}{
+		{name: "same host", u1: "https://example.com/a", u2: "https://example.com/b", want: true},
+		{name: "different subdomains, same eTLD+1", u1: "https://www.example.com", u2: "https://api.example.com", want: true},
+		{name: "different registrable domains under a multi-label suffix", u1: "https://foo.co.uk", u2: "https://bar.co.uk", want: false},
+		{name: "same registrable domain under a multi-label suffix", u1: "https://www.foo.co.uk", u2: "https://shop.foo.co.uk", want: true},
+		{name: "different users under a private multi-label suffix", u1: "https://a.github.io", u2: "https://b.github.io", want: false},
+		{name: "same user under a private multi-label suffix", u1: "https://a.github.io/repo1", u2: "https://a.github.io/repo2", want: true},
+		{name: "unrelated domains", u1: "https://example.com", u2: "https://example.net", want: false},
+		{name: "localhost falls back to exact host match", u1: "http://localhost:8080", u2: "http://localhost:3000", want: true},
+		{name: "different hosts with no registrable domain", u1: "http://localhost", u2: "http://127.0.0.1", want: false},
+		{name: "IP literal falls back to exact host match", u1: "http://127.0.0.1:8080", u2: "http://127.0.0.1:9090", want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SameSite(tt.u1, tt.u2); got != tt.want {
+				t.Errorf("SameSite(%q, %q) = %v, want %v", tt.u1, tt.u2, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRegistrableDomain(t *testing.T) {
+	tests := []struct {
+		name    string
+		host    string
+		want    string
+		wantErr bool
+	}{
+		{name: "plain domain", host: "example.com", want: "example.com"},
+		{name: "subdomain", host: "www.example.com", want: "example.com"},
+		{name: "multi-label suffix", host: "www.foo.co.uk", want: "foo.co.uk"},
+		{name: "private suffix treated as public", host: "a.github.io", want: "a.github.io"},
+		{name: "bucket-style subdomain under a private suffix", host: "my-bucket.s3.amazonaws.com", want: "my-bucket.s3.amazonaws.com"},
+		{name: "bare eTLD has no registrable domain", host: "co.uk", wantErr: true},
+		{name: "unknown TLD has no registrable domain", host: "localhost", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := registrableDomain(tt.host)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("registrableDomain(%q) = %q, nil, want an error", tt.host, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("registrableDomain(%q) returned unexpected error: %v", tt.host, err)
+			}
+			if got != tt.want {
+				t.Errorf("registrableDomain(%q) = %q, want %q", tt.host, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScopeInScope(t *testing.T) {
+	rules := []Rule{
+		{Allow: true, Pattern: "*.examplecdn.com"},
+		{Allow: false, Pattern: "tracking.example.com"},
+		{Allow: false, Pattern: "static.examplecdn.com"},
+	}
+	s := New(rules)
+
+	tests := []struct {
+		name      string
+		candidate string
+		want      bool
+	}{
+		{name: "allow rule matches a CDN subdomain out of scope by default", candidate: "https://assets.examplecdn.com/app.js", want: true},
+		{name: "deny rule matches a subdomain that would otherwise be same-site", candidate: "https://tracking.example.com/pixel.js", want: false},
+		{name: "falls back to same-site when no rule matches", candidate: "https://www.example.com/app.js", want: true},
+		{name: "falls back to same-site and rejects an unrelated domain", candidate: "https://evil.com/app.js", want: false},
+		{name: "first matching rule wins even if a later rule would also match", candidate: "https://static.examplecdn.com/x.js", want: true},
+	}
+
+	base := "https://www.example.com"
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := s.InScope(base, tt.candidate); got != tt.want {
+				t.Errorf("InScope(%q, %q) = %v, want %v", base, tt.candidate, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScopeInScopeNoRules(t *testing.T) {
+	s := New(nil)
+	if !s.InScope("https://example.com", "https://www.example.com") {
+		t.Error("InScope with no rules should fall back to SameSite and allow a same-site URL")
+	}
+	if s.InScope("https://example.com", "https://evil.com") {
+		t.Error("InScope with no rules should fall back to SameSite and reject a different site")
+	}
+}
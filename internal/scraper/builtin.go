@@ -0,0 +1,187 @@
+package scraper
+
+import (
+	"regexp"
+	"strings"
+)
+
+// RegexScraper reports every match of Pattern as a single finding, with
+// Severity classifying each match (defaulting to "info" if Severity is nil).
+type RegexScraper struct {
+	IDValue  string
+	Output_  string
+	Pattern  *regexp.Regexp
+	Severity func(match string) string
+}
+
+func (s *RegexScraper) ID() string     { return s.IDValue }
+func (s *RegexScraper) Output() string { return s.Output_ }
+
+func (s *RegexScraper) Scan(content string) []Match {
+	locs := s.Pattern.FindAllStringIndex(content, -1)
+	if len(locs) == 0 {
+		return nil
+	}
+
+	out := make([]Match, 0, len(locs))
+	for _, loc := range locs {
+		m := content[loc[0]:loc[1]]
+		severity := "info"
+		if s.Severity != nil {
+			severity = s.Severity(m)
+		}
+		out = append(out, Match{ScraperID: s.IDValue, Output: s.Output_, Severity: severity, Text: m, Offset: loc[0]})
+	}
+	return out
+}
+
+// PairScraper reports HTTP-method/endpoint pairs extracted from Pattern's
+// submatches, e.g. `axios.post("/v1/signup")` or `fetch(url, {method: ...})`.
+// MethodGroup and EndpointGroup are 1-based submatch indices.
+type PairScraper struct {
+	IDValue       string
+	Pattern       *regexp.Regexp
+	MethodGroup   int
+	EndpointGroup int
+	Severity      func(endpoint string) string
+}
+
+func (s *PairScraper) ID() string     { return s.IDValue }
+func (s *PairScraper) Output() string { return OutputAPI }
+
+func (s *PairScraper) Scan(content string) []Match {
+	submatches := s.Pattern.FindAllStringSubmatchIndex(content, -1)
+	if len(submatches) == 0 {
+		return nil
+	}
+
+	out := make([]Match, 0, len(submatches))
+	for _, idx := range submatches {
+		methodStart, methodEnd := idx[2*s.MethodGroup], idx[2*s.MethodGroup+1]
+		endpointStart, endpointEnd := idx[2*s.EndpointGroup], idx[2*s.EndpointGroup+1]
+		if methodStart < 0 || endpointStart < 0 {
+			continue
+		}
+		method := strings.ToUpper(content[methodStart:methodEnd])
+		endpoint := strings.ReplaceAll(content[endpointStart:endpointEnd], "${}", "")
+
+		severity := "info"
+		if s.Severity != nil {
+			severity = s.Severity(endpoint)
+		}
+		text := content[idx[0]:idx[1]]
+		out = append(out, Match{ScraperID: s.IDValue, Output: OutputAPI, Severity: severity, Text: text, Method: method, Endpoint: endpoint, Offset: idx[0]})
+	}
+	return out
+}
+
+// DefaultSeverity is kneejerk's original env-var/endpoint heuristic: AWS
+// access keys and anything with "SECRET" are high, other AWS vars are
+// medium, API URLs/hosts are low, everything else is informational.
+func DefaultSeverity(match string) string {
+	upper := strings.ToUpper(match)
+	switch {
+	case strings.Contains(upper, "AWS") && (strings.Contains(upper, "ACCESS") && (strings.Contains(upper, "ID") || strings.Contains(upper, "KEY"))) || strings.Contains(upper, "SECRET"):
+		return "high"
+	case strings.Contains(upper, "AWS"):
+		return "medium"
+	case strings.Contains(upper, "API") && (strings.Contains(upper, "URL") || strings.Contains(upper, "HOST") || strings.Contains(upper, "ROOT")):
+		return "low"
+	default:
+		return "info"
+	}
+}
+
+func constant(severity string) func(string) string {
+	return func(string) string { return severity }
+}
+
+// Builtins returns kneejerk's built-in scraper pack: direct env var
+// assignments, API call sites (plain literals, axios, fetch, $.ajax), and a
+// handful of well-known secret/token formats.
+func Builtins() []Scraper {
+	return []Scraper{
+		&RegexScraper{
+			IDValue:  "env-var",
+			Output_:  OutputEnvVar,
+			Pattern:  regexp.MustCompile(`\b(?:NODE|REACT_APP|AWS)_?[A-Z_]*\b\s*:\s*".*?"`),
+			Severity: DefaultSeverity,
+		},
+		&PairScraper{
+			IDValue:       "api-path",
+			Pattern:       regexp.MustCompile(`"(GET|POST|PUT|DELETE|PATCH)",\s*"(/v\d+[^"]*)"`),
+			MethodGroup:   1,
+			EndpointGroup: 2,
+			Severity:      DefaultSeverity,
+		},
+		&PairScraper{
+			IDValue:       "axios-call",
+			Pattern:       regexp.MustCompile(`axios\.(get|post|put|delete|patch)\(\s*['"]([^'"]+)['"]`),
+			MethodGroup:   1,
+			EndpointGroup: 2,
+			Severity:      DefaultSeverity,
+		},
+		&PairScraper{
+			IDValue:       "fetch-call",
+			Pattern:       regexp.MustCompile(`fetch\(\s*['"]([^'"]+)['"],[\s\S]*?{[\s\S]*?method\s*:\s*['"]([^'"]+)['"]`),
+			MethodGroup:   2,
+			EndpointGroup: 1,
+			Severity:      DefaultSeverity,
+		},
+		&PairScraper{
+			IDValue:       "ajax-call",
+			Pattern:       regexp.MustCompile(`\$\.ajax\(\s*{\s*url\s*:\s*['"]([^'"]+)['"],[\s\S]*?type\s*:\s*['"]([^'"]+)['"]`),
+			MethodGroup:   2,
+			EndpointGroup: 1,
+			Severity:      DefaultSeverity,
+		},
+		&RegexScraper{
+			IDValue:  "jwt",
+			Output_:  OutputSecret,
+			Pattern:  regexp.MustCompile(`eyJ[A-Za-z0-9_-]{10,}\.eyJ[A-Za-z0-9_-]{10,}\.[A-Za-z0-9_-]{10,}`),
+			Severity: constant("high"),
+		},
+		&RegexScraper{
+			IDValue:  "google-api-key",
+			Output_:  OutputSecret,
+			Pattern:  regexp.MustCompile(`AIza[0-9A-Za-z\-_]{35}`),
+			Severity: constant("high"),
+		},
+		&RegexScraper{
+			IDValue:  "stripe-key",
+			Output_:  OutputSecret,
+			Pattern:  regexp.MustCompile(`\bsk_(?:live|test)_[0-9a-zA-Z]{24,}\b`),
+			Severity: constant("high"),
+		},
+		&RegexScraper{
+			IDValue:  "github-token",
+			Output_:  OutputSecret,
+			Pattern:  regexp.MustCompile(`\bgh[pousr]_[A-Za-z0-9]{36}\b`),
+			Severity: constant("high"),
+		},
+		&RegexScraper{
+			IDValue:  "slack-token",
+			Output_:  OutputSecret,
+			Pattern:  regexp.MustCompile(`\bxox[baprs]-[0-9A-Za-z-]{10,}\b`),
+			Severity: constant("high"),
+		},
+		&RegexScraper{
+			IDValue:  "firebase-config",
+			Output_:  OutputSecret,
+			Pattern:  regexp.MustCompile(`"authDomain"\s*:\s*"[^"]+\.firebaseapp\.com"`),
+			Severity: constant("medium"),
+		},
+		&RegexScraper{
+			IDValue:  "graphql-endpoint",
+			Output_:  OutputSecret,
+			Pattern:  regexp.MustCompile(`["'][^"']*/graphql["']`),
+			Severity: constant("info"),
+		},
+		&RegexScraper{
+			IDValue:  "bearer-auth",
+			Output_:  OutputSecret,
+			Pattern:  regexp.MustCompile(`(?i)(?:authorization['"]?\s*[:=]\s*['"]?)?Bearer\s+[A-Za-z0-9\-_.=]{10,}`),
+			Severity: constant("medium"),
+		},
+	}
+}
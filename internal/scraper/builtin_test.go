@@ -0,0 +1,127 @@
+package scraper
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestRegexScraperScan(t *testing.T) {
+	s := &RegexScraper{
+		IDValue: "test-secret",
+		Output_: OutputSecret,
+		Pattern: regexp.MustCompile(`sk_live_[0-9a-zA-Z]{5}`),
+	}
+
+	matches := s.Scan(`const key = "sk_live_abcde"; console.log(key);`)
+	if len(matches) != 1 {
+		t.Fatalf("got %d matches, want 1", len(matches))
+	}
+	m := matches[0]
+	if m.ScraperID != "test-secret" || m.Output != OutputSecret {
+		t.Errorf("match = %+v, want ScraperID/Output test-secret/%s", m, OutputSecret)
+	}
+	if m.Text != "sk_live_abcde" {
+		t.Errorf("Text = %q, want %q", m.Text, "sk_live_abcde")
+	}
+	if m.Severity != "info" {
+		t.Errorf("Severity = %q, want default %q", m.Severity, "info")
+	}
+}
+
+func TestRegexScraperNoMatch(t *testing.T) {
+	s := &RegexScraper{Pattern: regexp.MustCompile(`nope`)}
+	if matches := s.Scan("nothing to see here"); matches != nil {
+		t.Errorf("Scan() = %+v, want nil", matches)
+	}
+}
+
+func TestRegexScraperUsesCustomSeverity(t *testing.T) {
+	s := &RegexScraper{
+		Pattern:  regexp.MustCompile(`hit`),
+		Severity: constant("high"),
+	}
+	matches := s.Scan("a hit here")
+	if len(matches) != 1 || matches[0].Severity != "high" {
+		t.Fatalf("matches = %+v, want a single high-severity match", matches)
+	}
+}
+
+func TestPairScraperScan(t *testing.T) {
+	s := &PairScraper{
+		IDValue:       "axios-call",
+		Pattern:       regexp.MustCompile(`axios\.(get|post|put|delete|patch)\(\s*['"]([^'"]+)['"]`),
+		MethodGroup:   1,
+		EndpointGroup: 2,
+		Severity:      DefaultSeverity,
+	}
+
+	matches := s.Scan(`axios.post("/v1/signup")`)
+	if len(matches) != 1 {
+		t.Fatalf("got %d matches, want 1", len(matches))
+	}
+	m := matches[0]
+	if m.Output != OutputAPI {
+		t.Errorf("Output = %q, want %q", m.Output, OutputAPI)
+	}
+	if m.Method != "POST" {
+		t.Errorf("Method = %q, want %q", m.Method, "POST")
+	}
+	if m.Endpoint != "/v1/signup" {
+		t.Errorf("Endpoint = %q, want %q", m.Endpoint, "/v1/signup")
+	}
+}
+
+func TestPairScraperStripsTemplatePlaceholders(t *testing.T) {
+	s := &PairScraper{
+		Pattern:       regexp.MustCompile(`"(GET|POST)",\s*"([^"]*)"`),
+		MethodGroup:   1,
+		EndpointGroup: 2,
+	}
+
+	matches := s.Scan(`"GET", "/v1/users/${}/profile"`)
+	if len(matches) != 1 {
+		t.Fatalf("got %d matches, want 1", len(matches))
+	}
+	if got := matches[0].Endpoint; got != "/v1/users//profile" {
+		t.Errorf("Endpoint = %q, want template placeholder stripped", got)
+	}
+}
+
+func TestDefaultSeverity(t *testing.T) {
+	tests := []struct {
+		name  string
+		match string
+		want  string
+	}{
+		{name: "AWS access key id", match: `AWS_ACCESS_KEY_ID: "AKIA..."`, want: "high"},
+		{name: "generic secret", match: `API_SECRET: "shh"`, want: "high"},
+		{name: "other AWS var", match: `AWS_REGION: "us-east-1"`, want: "medium"},
+		{name: "API URL", match: `REACT_APP_API_URL: "https://api.example.com"`, want: "low"},
+		{name: "unremarkable env var", match: `NODE_ENV: "production"`, want: "info"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DefaultSeverity(tt.match); got != tt.want {
+				t.Errorf("DefaultSeverity(%q) = %q, want %q", tt.match, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuiltinsCoverEveryOutputType(t *testing.T) {
+	builtins := Builtins()
+	if len(builtins) == 0 {
+		t.Fatal("Builtins() returned no scrapers")
+	}
+
+	seen := make(map[string]bool)
+	for _, s := range builtins {
+		seen[s.Output()] = true
+	}
+	for _, output := range []string{OutputEnvVar, OutputAPI, OutputSecret} {
+		if !seen[output] {
+			t.Errorf("Builtins() has no scraper producing output type %q", output)
+		}
+	}
+}
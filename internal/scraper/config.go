@@ -0,0 +1,72 @@
+package scraper
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Rule is a single user-defined scraper loaded from a rules file.
+type Rule struct {
+	ID       string `yaml:"id" json:"id"`
+	Output   string `yaml:"outputType" json:"outputType"`
+	Pattern  string `yaml:"pattern" json:"pattern"`
+	Severity string `yaml:"severity" json:"severity"`
+}
+
+// RuleFile is the top-level shape of a `-scrapers` rules file.
+type RuleFile struct {
+	Rules []Rule `yaml:"rules" json:"rules"`
+}
+
+// LoadConfig reads a YAML or JSON rules file (selected by extension) and
+// compiles each rule into a RegexScraper. A rule with an invalid pattern
+// fails the whole load, with an error identifying the offending rule id.
+func LoadConfig(path string) ([]Scraper, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read scrapers config %s: %w", path, err)
+	}
+
+	var file RuleFile
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		err = json.Unmarshal(data, &file)
+	default:
+		err = yaml.Unmarshal(data, &file)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parse scrapers config %s: %w", path, err)
+	}
+
+	scrapers := make([]Scraper, 0, len(file.Rules))
+	for _, rule := range file.Rules {
+		re, err := regexp.Compile(rule.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("rule %q: invalid pattern: %w", rule.ID, err)
+		}
+
+		output := rule.Output
+		if output == "" {
+			output = OutputSecret
+		}
+		severity := rule.Severity
+		if severity == "" {
+			severity = "info"
+		}
+
+		scrapers = append(scrapers, &RegexScraper{
+			IDValue:  rule.ID,
+			Output_:  output,
+			Pattern:  re,
+			Severity: constant(severity),
+		})
+	}
+
+	return scrapers, nil
+}
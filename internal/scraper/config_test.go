@@ -0,0 +1,88 @@
+package scraper
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadConfigYAML(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "rules.yaml")
+	writeFile(t, path, `
+rules:
+  - id: internal-token
+    outputType: secret
+    pattern: 'itok_[0-9a-f]{8}'
+    severity: high
+`)
+
+	scrapers, err := LoadConfig(path)
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+	if len(scrapers) != 1 {
+		t.Fatalf("got %d scrapers, want 1", len(scrapers))
+	}
+	if scrapers[0].ID() != "internal-token" || scrapers[0].Output() != OutputSecret {
+		t.Errorf("scraper = %+v, want id=internal-token output=%s", scrapers[0], OutputSecret)
+	}
+
+	matches := scrapers[0].Scan("token=itok_deadbeef")
+	if len(matches) != 1 || matches[0].Severity != "high" {
+		t.Errorf("Scan() = %+v, want a single high-severity match", matches)
+	}
+}
+
+func TestLoadConfigJSON(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "rules.json")
+	writeFile(t, path, `{"rules":[{"id":"custom","pattern":"foo\\d+"}]}`)
+
+	scrapers, err := LoadConfig(path)
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+	if len(scrapers) != 1 {
+		t.Fatalf("got %d scrapers, want 1", len(scrapers))
+	}
+
+	// Output and severity default when a rule omits them.
+	matches := scrapers[0].Scan("foo123")
+	if len(matches) != 1 {
+		t.Fatalf("got %d matches, want 1", len(matches))
+	}
+	if matches[0].Output != OutputSecret {
+		t.Errorf("Output = %q, want default %q", matches[0].Output, OutputSecret)
+	}
+	if matches[0].Severity != "info" {
+		t.Errorf("Severity = %q, want default %q", matches[0].Severity, "info")
+	}
+}
+
+func TestLoadConfigInvalidPattern(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "rules.yaml")
+	writeFile(t, path, `
+rules:
+  - id: broken
+    pattern: '[unterminated'
+`)
+
+	if _, err := LoadConfig(path); err == nil {
+		t.Fatal("LoadConfig() error = nil, want an error for an invalid regex")
+	}
+}
+
+func TestLoadConfigMissingFile(t *testing.T) {
+	if _, err := LoadConfig(filepath.Join(t.TempDir(), "missing.yaml")); err == nil {
+		t.Fatal("LoadConfig() error = nil, want an error for a missing file")
+	}
+}
+
+func writeFile(t *testing.T, path string, contents string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+		t.Fatalf("writing test fixture %s: %v", path, err)
+	}
+}
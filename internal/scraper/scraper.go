@@ -0,0 +1,74 @@
+// Package scraper defines the pluggable extraction interface kneejerk runs
+// against every fetched JS file, and the registry that built-in and
+// user-defined rules are loaded into.
+package scraper
+
+import "sync"
+
+// Output types a Scraper's matches are reported under.
+const (
+	OutputEnvVar = "env-var"
+	OutputAPI    = "api"
+	OutputSecret = "secret"
+)
+
+// Match is a single finding produced by a Scraper. Method and Endpoint are
+// only populated for OutputAPI matches; every other type carries its finding
+// in Text.
+type Match struct {
+	ScraperID string
+	Output    string
+	Severity  string
+	Text      string
+	Method    string
+	Endpoint  string
+	// Offset is the byte offset of the match within the scanned content.
+	Offset int
+}
+
+// Scraper extracts findings of one kind from a JS file's content. Built-in
+// scrapers are regex-based (see RegexScraper and PairScraper); user rules
+// loaded via LoadConfig are RegexScraper instances too.
+type Scraper interface {
+	ID() string
+	Output() string
+	Scan(content string) []Match
+}
+
+// Registry holds every Scraper kneejerk will run against a JS file: the
+// built-in packs plus anything loaded from a user rules file.
+type Registry struct {
+	mu       sync.RWMutex
+	scrapers []Scraper
+}
+
+// NewRegistry returns an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// Register adds s to the registry.
+func (r *Registry) Register(s Scraper) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.scrapers = append(r.scrapers, s)
+}
+
+// All returns every registered Scraper.
+func (r *Registry) All() []Scraper {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	out := make([]Scraper, len(r.scrapers))
+	copy(out, r.scrapers)
+	return out
+}
+
+// Scan runs every registered Scraper against content and returns the
+// combined matches.
+func (r *Registry) Scan(content string) []Match {
+	var out []Match
+	for _, s := range r.All() {
+		out = append(out, s.Scan(content)...)
+	}
+	return out
+}
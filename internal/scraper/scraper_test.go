@@ -0,0 +1,41 @@
+package scraper
+
+import "testing"
+
+type stubScraper struct {
+	id     string
+	output string
+	text   string
+}
+
+func (s *stubScraper) ID() string     { return s.id }
+func (s *stubScraper) Output() string { return s.output }
+func (s *stubScraper) Scan(content string) []Match {
+	return []Match{{ScraperID: s.id, Output: s.output, Text: s.text}}
+}
+
+func TestRegistryScanCombinesAllScrapers(t *testing.T) {
+	r := NewRegistry()
+	r.Register(&stubScraper{id: "a", output: OutputSecret, text: "one"})
+	r.Register(&stubScraper{id: "b", output: OutputEnvVar, text: "two"})
+
+	matches := r.Scan("irrelevant content")
+	if len(matches) != 2 {
+		t.Fatalf("got %d matches, want 2", len(matches))
+	}
+	if matches[0].ScraperID != "a" || matches[1].ScraperID != "b" {
+		t.Errorf("matches = %+v, want scrapers in registration order", matches)
+	}
+}
+
+func TestRegistryAllReturnsACopy(t *testing.T) {
+	r := NewRegistry()
+	r.Register(&stubScraper{id: "a"})
+
+	all := r.All()
+	all[0] = &stubScraper{id: "mutated"}
+
+	if r.All()[0].ID() != "a" {
+		t.Error("mutating the slice returned by All() affected the registry")
+	}
+}
@@ -0,0 +1,187 @@
+// Package sourcemap parses version-3 JavaScript source maps and
+// reconstructs the original sources they describe, so kneejerk can scan
+// pre-bundle source files (and attribute bundle-level matches back to
+// them) instead of only the minified output.
+package sourcemap
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// sourceMappingURLPattern matches both the modern `//#` and legacy `//@`
+// forms of the sourceMappingURL comment. It is not anchored; FindDirectives
+// applies it to each line separately.
+var sourceMappingURLPattern = regexp.MustCompile(`//[#@]\s*sourceMappingURL=(\S+)`)
+
+// FindDirectives scans every line of content for a sourceMappingURL
+// comment and returns the raw URL/path of each one found, in the order
+// they appear. Unlike checking only the final line, this catches maps
+// referenced mid-file (common once a bundle has trailing boilerplate after
+// the comment) and files with more than one such comment.
+func FindDirectives(content string) []string {
+	var urls []string
+	for _, line := range strings.Split(content, "\n") {
+		if m := sourceMappingURLPattern.FindStringSubmatch(line); m != nil {
+			urls = append(urls, m[1])
+		}
+	}
+	return urls
+}
+
+// Map is a parsed version-3 source map.
+type Map struct {
+	Version        int      `json:"version"`
+	File           string   `json:"file"`
+	SourceRoot     string   `json:"sourceRoot"`
+	Sources        []string `json:"sources"`
+	SourcesContent []string `json:"sourcesContent"`
+	Names          []string `json:"names"`
+	Mappings       string   `json:"mappings"`
+
+	lines [][]segment
+}
+
+// segment is one decoded VLQ group from the mappings string: the start of
+// a region in the generated file and where it came from in a source.
+type segment struct {
+	genCol    int
+	source    int
+	srcLine   int
+	srcCol    int
+	hasSource bool
+}
+
+// Parse decodes a raw version-3 source map document.
+func Parse(data []byte) (*Map, error) {
+	var m Map
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("parse source map: %w", err)
+	}
+	if m.Version != 0 && m.Version != 3 {
+		return nil, fmt.Errorf("parse source map: unsupported version %d", m.Version)
+	}
+	m.lines = decodeMappings(m.Mappings)
+	return &m, nil
+}
+
+// SourcePath returns the sources[i] entry joined with SourceRoot, matching
+// how a v3 consumer resolves the original file's path.
+func (m *Map) SourcePath(i int) string {
+	if i < 0 || i >= len(m.Sources) {
+		return ""
+	}
+	if m.SourceRoot == "" {
+		return m.Sources[i]
+	}
+	return strings.TrimSuffix(m.SourceRoot, "/") + "/" + m.Sources[i]
+}
+
+// SourceContent returns the inlined original content for sources[i], if the
+// map carries one.
+func (m *Map) SourceContent(i int) (string, bool) {
+	if i < 0 || i >= len(m.SourcesContent) {
+		return "", false
+	}
+	if m.SourcesContent[i] == "" {
+		return "", false
+	}
+	return m.SourcesContent[i], true
+}
+
+// WriteSources writes every source with inlined content to dir, using the
+// sources[] path (sanitized to stay within dir) and creating subdirectories
+// as needed. It returns the paths written, indexed the same as Sources.
+func (m *Map) WriteSources(dir string) ([]string, error) {
+	written := make([]string, len(m.Sources))
+	for i := range m.Sources {
+		content, ok := m.SourceContent(i)
+		if !ok {
+			continue
+		}
+
+		rel := sanitizeRelPath(m.SourcePath(i))
+		dest := filepath.Join(dir, rel)
+		if err := os.MkdirAll(filepath.Dir(dest), 0o755); err != nil {
+			return nil, fmt.Errorf("write source %s: %w", rel, err)
+		}
+		if err := os.WriteFile(dest, []byte(content), 0o644); err != nil {
+			return nil, fmt.Errorf("write source %s: %w", rel, err)
+		}
+		written[i] = dest
+	}
+	return written, nil
+}
+
+// sanitizeRelPath strips any scheme, leading slashes, and ".." segments so
+// a malicious sources[] entry can't escape dir in WriteSources.
+func sanitizeRelPath(p string) string {
+	if idx := strings.Index(p, "://"); idx != -1 {
+		p = p[idx+3:]
+	}
+	p = strings.TrimPrefix(p, "/")
+
+	var parts []string
+	for _, part := range strings.Split(p, "/") {
+		switch part {
+		case "", ".", "..":
+			continue
+		default:
+			parts = append(parts, part)
+		}
+	}
+	if len(parts) == 0 {
+		return "unnamed-source"
+	}
+	return filepath.Join(parts...)
+}
+
+// OriginalPosition maps a 0-based (line, column) in the generated file to
+// the source file and 1-based line it came from, via the decoded VLQ
+// mappings. ok is false if genLine has no mapping (e.g. the map has no
+// mappings at all, or the position falls before the first segment).
+func (m *Map) OriginalPosition(genLine, genCol int) (file string, line int, ok bool) {
+	if genLine < 0 || genLine >= len(m.lines) {
+		return "", 0, false
+	}
+
+	segs := m.lines[genLine]
+	var best *segment
+	for i := range segs {
+		if segs[i].genCol > genCol {
+			break
+		}
+		best = &segs[i]
+	}
+	if best == nil || !best.hasSource {
+		return "", 0, false
+	}
+
+	path := m.SourcePath(best.source)
+	if path == "" {
+		return "", 0, false
+	}
+
+	return path, best.srcLine + 1, true
+}
+
+// LineCol converts a 0-based byte offset within content into a 0-based
+// (line, column) pair, matching the coordinate system OriginalPosition and
+// the decoded mappings use.
+func LineCol(content string, offset int) (line, col int) {
+	if offset > len(content) {
+		offset = len(content)
+	}
+	upTo := content[:offset]
+	line = strings.Count(upTo, "\n")
+	if idx := strings.LastIndexByte(upTo, '\n'); idx != -1 {
+		col = len(upTo) - idx - 1
+	} else {
+		col = len(upTo)
+	}
+	return line, col
+}
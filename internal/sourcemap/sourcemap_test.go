@@ -0,0 +1,72 @@
+package sourcemap
+
+import "testing"
+
+func TestOriginalPosition(t *testing.T) {
+	// Same mappings as TestDecodeMappings: four generated lines, with a
+	// sourceless segment (line 1) and an empty line (line 2) mixed in so
+	// both "no mapping here" paths get exercised alongside the normal one.
+	m := &Map{
+		Sources: []string{"src/a.js"},
+		lines:   decodeMappings("AAAA,IAAI;E;;KAAA"),
+	}
+
+	tests := []struct {
+		name     string
+		genLine  int
+		genCol   int
+		wantFile string
+		wantLine int
+		wantOK   bool
+	}{
+		{name: "exact match on first segment", genLine: 0, genCol: 0, wantFile: "src/a.js", wantLine: 1, wantOK: true},
+		{name: "falls between segments picks preceding one", genLine: 0, genCol: 100, wantFile: "src/a.js", wantLine: 1, wantOK: true},
+		{name: "segment with no source", genLine: 1, genCol: 5, wantOK: false},
+		{name: "line with no segments", genLine: 2, genCol: 0, wantOK: false},
+		{name: "before first segment on its line", genLine: 3, genCol: 0, wantOK: false},
+		{name: "at the only segment on its line", genLine: 3, genCol: 5, wantFile: "src/a.js", wantLine: 1, wantOK: true},
+		{name: "generated line out of range", genLine: 10, genCol: 0, wantOK: false},
+		{name: "negative generated line", genLine: -1, genCol: 0, wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file, line, ok := m.OriginalPosition(tt.genLine, tt.genCol)
+			if ok != tt.wantOK {
+				t.Fatalf("OriginalPosition(%d, %d) ok = %v, want %v", tt.genLine, tt.genCol, ok, tt.wantOK)
+			}
+			if !tt.wantOK {
+				return
+			}
+			if file != tt.wantFile || line != tt.wantLine {
+				t.Errorf("OriginalPosition(%d, %d) = (%q, %d), want (%q, %d)", tt.genLine, tt.genCol, file, line, tt.wantFile, tt.wantLine)
+			}
+		})
+	}
+}
+
+func TestLineCol(t *testing.T) {
+	content := "line1\nline2\nline3"
+
+	tests := []struct {
+		name     string
+		offset   int
+		wantLine int
+		wantCol  int
+	}{
+		{name: "start of content", offset: 0, wantLine: 0, wantCol: 0},
+		{name: "end of first line", offset: 5, wantLine: 0, wantCol: 5},
+		{name: "start of second line", offset: 6, wantLine: 1, wantCol: 0},
+		{name: "middle of third line", offset: 14, wantLine: 2, wantCol: 2},
+		{name: "offset past end clamps to content length", offset: len(content) + 10, wantLine: 2, wantCol: 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			line, col := LineCol(content, tt.offset)
+			if line != tt.wantLine || col != tt.wantCol {
+				t.Errorf("LineCol(%q, %d) = (%d, %d), want (%d, %d)", content, tt.offset, line, col, tt.wantLine, tt.wantCol)
+			}
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package sourcemap
+
+import "strings"
+
+// base64Chars is the alphabet VLQ segments are encoded with, indexed by
+// decoded sextet value.
+const base64Chars = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789+/"
+
+var base64Decode [256]int
+
+func init() {
+	for i := range base64Decode {
+		base64Decode[i] = -1
+	}
+	for i := 0; i < len(base64Chars); i++ {
+		base64Decode[base64Chars[i]] = i
+	}
+}
+
+const (
+	vlqContinuationBit = 0x20
+	vlqValueMask       = 0x1f
+)
+
+// decodeVLQ reads one VLQ-encoded, base64-digit number from s and returns
+// it along with the remainder of s after the digits consumed.
+func decodeVLQ(s string) (value int, rest string, ok bool) {
+	shift := 0
+	result := 0
+	for i := 0; i < len(s); i++ {
+		digit := base64Decode[s[i]]
+		if digit == -1 {
+			return 0, s, false
+		}
+		result += (digit & vlqValueMask) << shift
+		if digit&vlqContinuationBit == 0 {
+			negative := result&1 == 1
+			result >>= 1
+			if negative {
+				result = -result
+			}
+			return result, s[i+1:], true
+		}
+		shift += 5
+	}
+	return 0, s, false
+}
+
+// decodeMappings decodes a source map's "mappings" string into per
+// generated-line segments, resolving the field-relative VLQ deltas into
+// absolute values as it goes.
+func decodeMappings(mappings string) [][]segment {
+	if mappings == "" {
+		return nil
+	}
+
+	genLines := strings.Split(mappings, ";")
+	out := make([][]segment, len(genLines))
+
+	srcIndex, srcLine, srcCol, nameIndex := 0, 0, 0, 0
+	for i, lineStr := range genLines {
+		genCol := 0
+		if lineStr == "" {
+			continue
+		}
+
+		var segs []segment
+		for _, group := range strings.Split(lineStr, ",") {
+			if group == "" {
+				continue
+			}
+
+			var fields []int
+			rest := group
+			for rest != "" {
+				v, r, ok := decodeVLQ(rest)
+				if !ok {
+					break
+				}
+				fields = append(fields, v)
+				rest = r
+			}
+			if len(fields) == 0 {
+				continue
+			}
+
+			genCol += fields[0]
+			seg := segment{genCol: genCol}
+			if len(fields) >= 4 {
+				srcIndex += fields[1]
+				srcLine += fields[2]
+				srcCol += fields[3]
+				seg.hasSource = true
+				seg.source = srcIndex
+				seg.srcLine = srcLine
+				seg.srcCol = srcCol
+			}
+			if len(fields) >= 5 {
+				nameIndex += fields[4]
+			}
+			segs = append(segs, seg)
+		}
+		out[i] = segs
+	}
+
+	return out
+}
@@ -0,0 +1,89 @@
+package sourcemap
+
+import "testing"
+
+func TestDecodeVLQ(t *testing.T) {
+	tests := []struct {
+		name     string
+		in       string
+		want     int
+		wantOK   bool
+		wantRest string
+	}{
+		{name: "zero", in: "A", want: 0, wantOK: true},
+		{name: "one", in: "C", want: 1, wantOK: true},
+		{name: "minus one", in: "D", want: -1, wantOK: true},
+		{name: "two quintets positive", in: "gB", want: 16, wantOK: true},
+		{name: "two quintets negative", in: "hB", want: -16, wantOK: true},
+		{name: "two quintets negative larger", in: "pG", want: -100, wantOK: true},
+		{name: "three quintets", in: "w+B", want: 1000, wantOK: true},
+		{name: "trailing digits kept as rest", in: "CC", want: 1, wantOK: true, wantRest: "C"},
+		{name: "empty input", in: "", want: 0, wantOK: false},
+		{name: "invalid char", in: "!", want: 0, wantOK: false},
+		{name: "unterminated continuation", in: "g", want: 0, wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, rest, ok := decodeVLQ(tt.in)
+			if ok != tt.wantOK {
+				t.Fatalf("decodeVLQ(%q) ok = %v, want %v", tt.in, ok, tt.wantOK)
+			}
+			if !tt.wantOK {
+				return
+			}
+			if got != tt.want {
+				t.Errorf("decodeVLQ(%q) = %d, want %d", tt.in, got, tt.want)
+			}
+			if rest != tt.wantRest {
+				t.Errorf("decodeVLQ(%q) rest = %q, want %q", tt.in, rest, tt.wantRest)
+			}
+		})
+	}
+}
+
+func TestDecodeMappings(t *testing.T) {
+	// Generated line 0: two segments, genCol 0 and 4, both mapping to
+	// source 0 / line 0 / col 0 and col 4 respectively.
+	// Generated line 1: one segment, genCol 2, with no source fields.
+	// Generated line 2: no segments at all.
+	// Generated line 3: one segment, genCol 5, mapping back to source 0.
+	lines := decodeMappings("AAAA,IAAI;E;;KAAA")
+
+	if len(lines) != 4 {
+		t.Fatalf("len(lines) = %d, want 4", len(lines))
+	}
+
+	if got := lines[0]; len(got) != 2 {
+		t.Fatalf("lines[0] has %d segments, want 2", len(got))
+	} else {
+		if got[0].genCol != 0 || !got[0].hasSource || got[0].source != 0 || got[0].srcLine != 0 || got[0].srcCol != 0 {
+			t.Errorf("lines[0][0] = %+v, want genCol=0 hasSource=true source=0 srcLine=0 srcCol=0", got[0])
+		}
+		if got[1].genCol != 4 || !got[1].hasSource || got[1].source != 0 || got[1].srcLine != 0 || got[1].srcCol != 4 {
+			t.Errorf("lines[0][1] = %+v, want genCol=4 hasSource=true source=0 srcLine=0 srcCol=4", got[1])
+		}
+	}
+
+	if got := lines[1]; len(got) != 1 {
+		t.Fatalf("lines[1] has %d segments, want 1", len(got))
+	} else if got[0].genCol != 2 || got[0].hasSource {
+		t.Errorf("lines[1][0] = %+v, want genCol=2 hasSource=false", got[0])
+	}
+
+	if got := lines[2]; len(got) != 0 {
+		t.Errorf("lines[2] has %d segments, want 0", len(got))
+	}
+
+	if got := lines[3]; len(got) != 1 {
+		t.Fatalf("lines[3] has %d segments, want 1", len(got))
+	} else if got[0].genCol != 5 || !got[0].hasSource || got[0].source != 0 || got[0].srcLine != 0 {
+		t.Errorf("lines[3][0] = %+v, want genCol=5 hasSource=true source=0 srcLine=0", got[0])
+	}
+}
+
+func TestDecodeMappingsEmpty(t *testing.T) {
+	if got := decodeMappings(""); got != nil {
+		t.Errorf("decodeMappings(\"\") = %v, want nil", got)
+	}
+}